@@ -0,0 +1,20 @@
+# translations/pt-BR.json holds {"config": {"name": "configuração", "description": "Muda as configurações"}}
+data "discord-interactions_localizations" "example" {
+  path = "${path.module}/translations"
+}
+
+resource "discord-interactions_global_command" "config" {
+  name        = "config"
+  description = "Change settings"
+
+  name_localizations        = jsondecode(data.discord-interactions_localizations.example.localizations["config.name"])
+  description_localizations = jsondecode(data.discord-interactions_localizations.example.localizations["config.description"])
+}
+
+# The localization list can also be turned into a map of key to locale map with a for expression
+locals {
+  localizations = {
+    for localization in data.discord-interactions_localizations.example.localization :
+    localization.key => localization.values
+  }
+}
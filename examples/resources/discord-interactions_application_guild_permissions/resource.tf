@@ -0,0 +1,16 @@
+# Only allow the bot's commands in #bot-commands
+resource "discord-interactions_application_guild_permissions" "example" {
+  guild_id = "386659935687147521"
+
+  permission {
+    type  = "channel"
+    id    = "all_channels"
+    allow = false
+  }
+
+  permission {
+    type  = "channel"
+    id    = "386660194866118656"
+    allow = true
+  }
+}
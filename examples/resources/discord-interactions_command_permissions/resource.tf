@@ -0,0 +1,30 @@
+resource "discord-interactions_guild_command" "ban" {
+  name               = "ban"
+  description        = "Ban a member"
+  guild_id           = "386659935687147521"
+  default_permission = false
+}
+
+resource "discord-interactions_command_permissions" "ban" {
+  guild_id   = "386659935687147521"
+  command_id = discord-interactions_guild_command.ban.id
+
+  # Moderators
+  permission {
+    type  = "role"
+    id    = "386660067170828288"
+    allow = true
+  }
+
+  permission {
+    type  = "role"
+    id    = "everyone"
+    allow = false
+  }
+
+  permission {
+    type  = "channel"
+    id    = "all_channels"
+    allow = true
+  }
+}
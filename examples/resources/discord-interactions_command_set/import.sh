@@ -0,0 +1,5 @@
+# Guild commands are imported by guild ID
+terraform import discord-interactions_command_set.example 386659935687147520
+
+# Global commands are imported as global
+terraform import discord-interactions_command_set.example global
@@ -0,0 +1,19 @@
+resource "discord-interactions_command_set" "example" {
+  guild_id = "386659935687147521"
+
+  command {
+    name        = "hello-world"
+    description = "Say hello to someone"
+
+    option {
+      type        = 6
+      name        = "user"
+      description = "Tell this person hello!"
+    }
+  }
+
+  command {
+    name        = "goodbye"
+    description = "Say goodbye"
+  }
+}
@@ -14,3 +14,43 @@ resource "discord-interactions_global_command" "example" {
     description = "What message do I send?"
   }
 }
+
+# Subcommands and subcommand groups nest their own options, up to three levels deep.
+resource "discord-interactions_global_command" "nested" {
+  name        = "role"
+  description = "Manage roles"
+
+  name_localizations = {
+    "pt-BR" = "cargo"
+    "ja"    = "ロール"
+  }
+  description_localizations = {
+    "pt-BR" = "Gerenciar cargos"
+    "ja"    = "ロールを管理する"
+  }
+
+  option {
+    type        = 2 # SUB_COMMAND_GROUP
+    name        = "color"
+    description = "Manage color roles"
+
+    option {
+      type        = 1 # SUB_COMMAND
+      name        = "add"
+      description = "Add a color role"
+
+      option {
+        type        = 8 # ROLE
+        name        = "role"
+        description = "Role to add"
+        required    = true
+      }
+    }
+  }
+
+  option {
+    type        = 1 # SUB_COMMAND
+    name        = "list"
+    description = "List your roles"
+  }
+}
@@ -15,3 +15,10 @@ resource "discord-interactions_guild_command" "example" {
     description = "What message do I send?"
   }
 }
+
+# Context menu commands show up when right clicking a message or user, and have no description or options.
+resource "discord-interactions_guild_command" "report" {
+  type     = "message"
+  name     = "Report Message"
+  guild_id = "386659935687147521"
+}
@@ -3,11 +3,14 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -17,11 +20,34 @@ type ClientConfig struct {
 	ApplicationID     string
 	UserAgent         string
 	APIRoot           string
+
+	// ClientSecret, if set, is used to fetch (and refresh) ClientCredentials with the OAuth2 client credentials grant.
+	ClientSecret string
+	// ClientID to use with ClientSecret. Defaults to ApplicationID, which is the same thing for most applications.
+	ClientID string
+	// Scopes to request with ClientSecret. Defaults to DefaultScopes.
+	Scopes []string
+
+	// MaxRetries is how many times a request failing with a 5xx or network error is tried again. 0 disables retries.
+	MaxRetries int
+	// MinRetryBackoff is the delay before the first retry, doubling with each following attempt. Defaults to 1s.
+	MinRetryBackoff time.Duration
+	// MaxRetryBackoff caps the delay between retries. Defaults to 30s.
+	MaxRetryBackoff time.Duration
 }
 
-// GetAuthHeader returns the header to use with Discord API calls.
-// If BotToken is set, it will use Bot ${BotToken}, or Bearer ${ClientCredentials} if the opposite is set.
-func (cc ClientConfig) GetAuthHeader() (string, error) {
+// GetAuthHeader returns the header to use with a Discord API call.
+// Editing permissions only accepts Bearer tokens, so that requires ClientCredentials.
+// Otherwise, if BotToken is set, it will use Bot ${BotToken}, or Bearer ${ClientCredentials} if the opposite is set.
+func (cc ClientConfig) GetAuthHeader(method string, path string) (string, error) {
+	if requiresBearer(method, path) {
+		if cc.ClientCredentials != "" {
+			return fmt.Sprintf("Bearer %s", cc.ClientCredentials), nil
+		}
+
+		return "", fmt.Errorf("%s %s requires ClientCredentials, Discord doesn't accept bot tokens there", method, path)
+	}
+
 	if cc.BotToken != "" {
 		return fmt.Sprintf("Bot %s", cc.BotToken), nil
 	}
@@ -33,18 +59,24 @@ func (cc ClientConfig) GetAuthHeader() (string, error) {
 	return "", fmt.Errorf("neither BotToken nor ClientCredentials is set")
 }
 
+// requiresBearer matches endpoints that reject bot tokens.
+func requiresBearer(method string, path string) bool {
+	return method != "GET" && strings.HasSuffix(path, "/permissions")
+}
+
 type InteractionsClient struct {
-	config     ClientConfig
-	authHeader string
-	httpClient *http.Client
+	config      ClientConfig
+	httpClient  *http.Client
+	rateLimiter *rateLimiter
+	tokens      *tokenSource
 }
 
 func NewInteractionsClient(config ClientConfig) (*InteractionsClient, error) {
-	authHeader, err := config.GetAuthHeader()
-	if err != nil {
-		return nil, err
+	if config.BotToken == "" && config.ClientCredentials == "" && config.ClientSecret == "" {
+		return nil, fmt.Errorf("none of BotToken, ClientCredentials or ClientSecret is set")
 	}
 
+	// Timeout only bounds a single attempt, the whole call (including retries and rate limit waits) is bounded by its context.
 	httpClient := &http.Client{
 		Timeout: time.Second * 30,
 	}
@@ -57,33 +89,82 @@ func NewInteractionsClient(config ClientConfig) (*InteractionsClient, error) {
 		return nil, fmt.Errorf("ApplicationID not set")
 	}
 
+	if config.MaxRetries < 0 {
+		return nil, fmt.Errorf("MaxRetries cannot be negative")
+	}
+
+	if config.MinRetryBackoff <= 0 {
+		config.MinRetryBackoff = defaultMinRetryBackoff
+	}
+
+	if config.MaxRetryBackoff <= 0 {
+		config.MaxRetryBackoff = defaultMaxRetryBackoff
+	}
+
+	if config.MaxRetryBackoff < config.MinRetryBackoff {
+		return nil, fmt.Errorf("MaxRetryBackoff cannot be less than MinRetryBackoff")
+	}
+
 	if config.UserAgent == "" {
 		config.UserAgent = "(+https://github.com/roleypoly/terraform-provider-discord-interactions)"
 	}
 
+	var tokens *tokenSource
+	if config.ClientSecret != "" {
+		if config.ClientID == "" {
+			config.ClientID = config.ApplicationID
+		}
+
+		if len(config.Scopes) == 0 {
+			config.Scopes = DefaultScopes
+		}
+
+		tokens = &tokenSource{
+			httpClient:   httpClient,
+			tokenURL:     config.APIRoot + "/oauth2/token",
+			clientID:     config.ClientID,
+			clientSecret: config.ClientSecret,
+			scopes:       config.Scopes,
+			userAgent:    config.UserAgent,
+		}
+	}
+
 	return &InteractionsClient{
-		config:     config,
-		authHeader: authHeader,
-		httpClient: httpClient,
+		config:      config,
+		httpClient:  httpClient,
+		rateLimiter: newRateLimiter(),
+		tokens:      tokens,
 	}, nil
 }
 
-func (i *InteractionsClient) makeRequest(method string, path string, body interface{}) (*http.Response, error) {
+// ApplicationID is the ID of the application whose commands this client manages.
+func (i *InteractionsClient) ApplicationID() string {
+	return i.config.ApplicationID
+}
+
+// authHeader picks the credential for a request, fetching a client credentials token first if one is needed.
+func (i *InteractionsClient) authHeader(ctx context.Context, method string, path string) (string, error) {
+	config := i.config
+
+	if i.tokens != nil && (config.BotToken == "" || requiresBearer(method, path)) {
+		token, err := i.tokens.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		config.ClientCredentials = token
+	}
+
+	return config.GetAuthHeader(method, path)
+}
+
+func (i *InteractionsClient) makeRequest(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
 	url, err := url.Parse(fmt.Sprintf("%s/applications/%s%s", i.config.APIRoot, i.config.ApplicationID, path))
 	if err != nil {
 		return nil, err
 	}
 
-	request := &http.Request{
-		Method: method,
-		URL:    url,
-		Header: map[string][]string{
-			"authorization": {i.authHeader},
-			"user-agent":    {i.config.UserAgent},
-			"content-type":  {"application/json"},
-		},
-	}
-
+	var bodyBytes []byte
 	if body != nil {
 		bodyBuffer := bytes.Buffer{}
 
@@ -92,13 +173,82 @@ func (i *InteractionsClient) makeRequest(method string, path string, body interf
 			return nil, err
 		}
 
-		request.Body = ioutil.NopCloser(&bodyBuffer)
+		bodyBytes = bodyBuffer.Bytes()
 	}
 
-	response, err := i.httpClient.Do(request)
-	return response, err
+	route := routeKey(method, path)
+	rateLimited, retries := 0, 0
+	reauthorized := false
+
+	// Every endpoint used by this client is idempotent (POST /commands upserts by name), so any request may be retried.
+	for {
+		err := i.rateLimiter.wait(ctx, route, path)
+		if err != nil {
+			return nil, err
+		}
+
+		// Fetched each attempt, as a client credentials token may have been refreshed since the last one.
+		authHeader, err := i.authHeader(ctx, method, path)
+		if err != nil {
+			return nil, err
+		}
+
+		request, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		request.Header.Set("authorization", authHeader)
+		request.Header.Set("user-agent", i.config.UserAgent)
+		request.Header.Set("content-type", "application/json")
+
+		if bodyBytes != nil {
+			request.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+			request.ContentLength = int64(len(bodyBytes))
+		}
+
+		response, err := i.httpClient.Do(request)
+		if err == nil {
+			i.rateLimiter.update(route, path, response)
+
+			if response.StatusCode == http.StatusTooManyRequests && rateLimited < maxRateLimitRetries {
+				rateLimited++
+				discardResponse(response)
+				continue
+			}
+
+			// A fetched token may be revoked before it expires, so it's replaced once before giving up.
+			if response.StatusCode == http.StatusUnauthorized && i.tokens != nil && !reauthorized && strings.HasPrefix(authHeader, "Bearer ") {
+				reauthorized = true
+				log.Printf("[WARN] %s %s was unauthorized, fetching a new client credentials token", method, path)
+				i.tokens.invalidate(strings.TrimPrefix(authHeader, "Bearer "))
+				discardResponse(response)
+				continue
+			}
+		}
+
+		if ctx.Err() == nil && retries < i.config.MaxRetries && shouldRetry(response, err) {
+			backoff := i.retryBackoff(retries)
+			retries++
+
+			if err != nil {
+				log.Printf("[WARN] %s %s failed, retrying in %s (attempt %d of %d): %v", method, path, backoff, retries, i.config.MaxRetries, err)
+			} else {
+				log.Printf("[WARN] %s %s returned %d, retrying in %s (attempt %d of %d)", method, path, response.StatusCode, backoff, retries, i.config.MaxRetries)
+			}
+
+			discardResponse(response)
+			if err := sleep(ctx, backoff); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return response, err
+	}
 }
 
-func (i *InteractionsClient) ErrFromResponse(response *http.Response, any ...interface{}) error {
-	return fmt.Errorf("InteractionsClient error for request: %s %s, response:\n  code: %d,\n  extra: %v", response.Request.Method, response.Request.URL.String(), response.StatusCode, any)
+// ErrFromResponse builds an *APIError from an unsuccessful response and its already-read body.
+func (i *InteractionsClient) ErrFromResponse(response *http.Response, body []byte) error {
+	return newAPIError(response, body)
 }
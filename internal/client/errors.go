@@ -0,0 +1,153 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Discord JSON error codes the provider cares about.
+// See: https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+const (
+	ErrorCodeUnknownApplicationCommand            = 10063
+	ErrorCodeUnknownApplicationCommandPermissions = 10066
+	ErrorCodeMissingAccess                        = 50001
+	ErrorCodeInvalidFormBody                      = 50035
+)
+
+// APIError is an unsuccessful response from the Discord API. Use errors.As to get one from a client error.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+
+	// Code is Discord's JSON error code, e.g. 10063 for "Unknown application command". 0 if the body wasn't JSON.
+	Code    int
+	Message string
+
+	// Errors are Discord's nested `errors` object, flattened into one entry per field.
+	Errors []FieldError
+
+	// Body is the raw response body, only kept when it isn't a Discord JSON error (e.g. a Cloudflare error page.)
+	Body string
+}
+
+// FieldError is a single validation error on a field of the request body.
+type FieldError struct {
+	// Path is the dotted path to the field in the request body, e.g. `options.0.name`
+	Path    string
+	Code    string
+	Message string
+}
+
+type apiErrorResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+type fieldErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// IsNotFound reports whether err is Discord saying the requested command doesn't exist.
+// Other unknown objects, like an Unknown Guild from a wrong guild_id, are configuration errors and aren't matched.
+// A 404 without a Discord error code is matched, as there's nothing better to go on.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.Code != 0 {
+		return apiErr.Code == ErrorCodeUnknownApplicationCommand
+	}
+
+	return apiErr.StatusCode == http.StatusNotFound
+}
+
+func newAPIError(response *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+	}
+
+	if response.Request != nil {
+		apiErr.Method = response.Request.Method
+		apiErr.URL = response.Request.URL.String()
+	}
+
+	errorResponse := apiErrorResponse{}
+	if err := json.Unmarshal(body, &errorResponse); err != nil || (errorResponse.Code == 0 && errorResponse.Message == "") {
+		apiErr.Body = string(body)
+		return apiErr
+	}
+
+	apiErr.Code = errorResponse.Code
+	apiErr.Message = errorResponse.Message
+	apiErr.Errors = flattenFieldErrors(nil, errorResponse.Errors)
+
+	sort.SliceStable(apiErr.Errors, func(a, b int) bool {
+		return apiErr.Errors[a].Path < apiErr.Errors[b].Path
+	})
+
+	return apiErr
+}
+
+// flattenFieldErrors walks Discord's nested error object, like `{"options": {"0": {"name": {"_errors": [...]}}}}`
+func flattenFieldErrors(path []string, raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	object := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil
+	}
+
+	fieldErrors := []FieldError{}
+	for key, value := range object {
+		if key != "_errors" {
+			fieldErrors = append(fieldErrors, flattenFieldErrors(append(path[:len(path):len(path)], key), value)...)
+			continue
+		}
+
+		errorResponses := []fieldErrorResponse{}
+		if err := json.Unmarshal(value, &errorResponses); err != nil {
+			continue
+		}
+
+		for _, errorResponse := range errorResponses {
+			fieldErrors = append(fieldErrors, FieldError{
+				Path:    strings.Join(path, "."),
+				Code:    errorResponse.Code,
+				Message: errorResponse.Message,
+			})
+		}
+	}
+
+	return fieldErrors
+}
+
+func (e *APIError) Error() string {
+	builder := strings.Builder{}
+
+	fmt.Fprintf(&builder, "Discord API error for request: %s %s, response:\n  status: %d", e.Method, e.URL, e.StatusCode)
+
+	if e.Code != 0 || e.Message != "" {
+		fmt.Fprintf(&builder, ",\n  code: %d,\n  message: %s", e.Code, e.Message)
+	}
+
+	for _, fieldError := range e.Errors {
+		fmt.Fprintf(&builder, "\n    %s: %s (%s)", fieldError.Path, fieldError.Code, fieldError.Message)
+	}
+
+	if e.Body != "" {
+		fmt.Fprintf(&builder, ",\n  body: %s", e.Body)
+	}
+
+	return builder.String()
+}
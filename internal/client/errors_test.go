@@ -0,0 +1,130 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+func TestAPIErrorIsDecoded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{
+			"code": 50035,
+			"message": "Invalid Form Body",
+			"errors": {
+				"options": {
+					"0": {"name": {"_errors": [{"code": "BASE_TYPE_BAD_LENGTH", "message": "Must be between 1 and 32 in length."}]}},
+					"2": {"choices": {"0": {"value": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}}}
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewInteractionsClient(client.ClientConfig{
+		ApplicationID: "386659935687147521",
+		BotToken:      "token",
+		APIRoot:       server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client, %v", err)
+	}
+
+	_, err = c.UpsertInteractionCommand(context.Background(), "", &client.InteractionCommand{Name: "hello-world"})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got: %v", err)
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != client.ErrorCodeInvalidFormBody || apiErr.Message != "Invalid Form Body" {
+		t.Errorf("unexpected error details, got: %v", apiErr)
+	}
+
+	expected := []client.FieldError{
+		{Path: "options.0.name", Code: "BASE_TYPE_BAD_LENGTH", Message: "Must be between 1 and 32 in length."},
+		{Path: "options.2.choices.0.value", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+	}
+
+	if len(apiErr.Errors) != len(expected) {
+		t.Fatalf("expected %d field errors, got: %v", len(expected), apiErr.Errors)
+	}
+
+	for i, fieldError := range expected {
+		if apiErr.Errors[i] != fieldError {
+			t.Errorf("field error %d did not match, got: %v, wanted: %v", i, apiErr.Errors[i], fieldError)
+		}
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		status   int
+		body     string
+		expected bool
+	}{
+		{
+			desc:     "unknown application command",
+			status:   http.StatusNotFound,
+			body:     `{"message": "Unknown application command", "code": 10063}`,
+			expected: true,
+		},
+		{
+			desc:     "unknown guild",
+			status:   http.StatusNotFound,
+			body:     `{"message": "Unknown Guild", "code": 10004}`,
+			expected: false,
+		},
+		{
+			desc:     "unknown application",
+			status:   http.StatusNotFound,
+			body:     `{"message": "Unknown Application", "code": 10002}`,
+			expected: false,
+		},
+		{
+			desc:     "404 without a code",
+			status:   http.StatusNotFound,
+			body:     `<html><body>Not Found</body></html>`,
+			expected: true,
+		},
+		{
+			desc:     "missing access",
+			status:   http.StatusForbidden,
+			body:     `{"message": "Missing Access", "code": 50001}`,
+			expected: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.WriteHeader(tC.status)
+				rw.Write([]byte(tC.body))
+			}))
+			defer server.Close()
+
+			c, err := client.NewInteractionsClient(client.ClientConfig{
+				ApplicationID: "386659935687147521",
+				BotToken:      "token",
+				APIRoot:       server.URL,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client, %v", err)
+			}
+
+			_, err = c.GetInteractionCommand(context.Background(), "", "1234")
+			if client.IsNotFound(err) != tC.expected {
+				t.Errorf("expected IsNotFound to be %v, got: %v", tC.expected, err)
+			}
+		})
+	}
+
+	if client.IsNotFound(errors.New("something else")) {
+		t.Errorf("expected an unrelated error not to be not found")
+	}
+}
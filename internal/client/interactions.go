@@ -1,21 +1,26 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 )
 
-func (i *InteractionsClient) GetInteractionCommands(guildID string) ([]*InteractionCommand, error) {
+func (i *InteractionsClient) GetInteractionCommands(ctx context.Context, guildID string) ([]*InteractionCommand, error) {
 	url := `/commands`
 	if guildID != "" {
 		url = `/guilds/` + guildID + url
 	}
 
-	response, err := i.makeRequest("GET", url, nil)
+	// Listing commands leaves out localizations unless asked for them.
+	url += `?with_localizations=true`
+
+	response, err := i.makeRequest(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("GET call to %s failed, %w", url, err)
 	}
+	defer response.Body.Close()
 
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
@@ -23,27 +28,28 @@ func (i *InteractionsClient) GetInteractionCommands(guildID string) ([]*Interact
 	}
 
 	if response.StatusCode != 200 {
-		return nil, i.ErrFromResponse(response)
+		return nil, i.ErrFromResponse(response, body)
 	}
 
 	commands := []*InteractionCommand{}
 	err = json.Unmarshal(body, &commands)
 	if err != nil {
-		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, i.ErrFromResponse(response))
+		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, err)
 	}
 	return commands, nil
 }
 
-func (i *InteractionsClient) GetInteractionCommand(guildID string, commandID string) (*InteractionCommand, error) {
+func (i *InteractionsClient) GetInteractionCommand(ctx context.Context, guildID string, commandID string) (*InteractionCommand, error) {
 	url := `/commands/` + commandID
 	if guildID != "" {
 		url = `/guilds/` + guildID + url
 	}
 
-	response, err := i.makeRequest("GET", url, nil)
+	response, err := i.makeRequest(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("GET call to %s failed, %w", url, err)
 	}
+	defer response.Body.Close()
 
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
@@ -51,18 +57,18 @@ func (i *InteractionsClient) GetInteractionCommand(guildID string, commandID str
 	}
 
 	if response.StatusCode != 200 {
-		return nil, i.ErrFromResponse(response)
+		return nil, i.ErrFromResponse(response, body)
 	}
 
 	command := &InteractionCommand{}
 	err = json.Unmarshal(body, command)
 	if err != nil {
-		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, i.ErrFromResponse(response))
+		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, err)
 	}
 	return command, nil
 }
 
-func (i *InteractionsClient) UpsertInteractionCommand(guildID string, command *InteractionCommand) (*InteractionCommand, error) {
+func (i *InteractionsClient) UpsertInteractionCommand(ctx context.Context, guildID string, command *InteractionCommand) (*InteractionCommand, error) {
 	url := `/commands`
 	if guildID != "" {
 		url = `/guilds/` + guildID + url
@@ -71,10 +77,11 @@ func (i *InteractionsClient) UpsertInteractionCommand(guildID string, command *I
 	// 	url = url + `/` + command.ID
 	// }
 
-	response, err := i.makeRequest("POST", url, command)
+	response, err := i.makeRequest(ctx, "POST", url, command)
 	if err != nil {
 		return nil, fmt.Errorf("POST call to %s failed: %w", url, err)
 	}
+	defer response.Body.Close()
 
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
@@ -88,25 +95,93 @@ func (i *InteractionsClient) UpsertInteractionCommand(guildID string, command *I
 	commandResponse := &InteractionCommand{}
 	err = json.Unmarshal(body, commandResponse)
 	if err != nil {
-		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, i.ErrFromResponse(response))
+		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, err)
+	}
+	return commandResponse, nil
+}
+
+// PatchInteractionCommand edits an existing command in place, keeping its ID even if it's renamed.
+func (i *InteractionsClient) PatchInteractionCommand(ctx context.Context, guildID string, commandID string, patch *InteractionCommandPatch) (*InteractionCommand, error) {
+	url := `/commands/` + commandID
+	if guildID != "" {
+		url = `/guilds/` + guildID + url
+	}
+
+	response, err := i.makeRequest(ctx, "PATCH", url, patch)
+	if err != nil {
+		return nil, fmt.Errorf("PATCH call to %s failed: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("response body unavilable, %w", err)
+	}
+
+	if response.StatusCode != 200 {
+		return nil, i.ErrFromResponse(response, body)
+	}
+
+	commandResponse := &InteractionCommand{}
+	err = json.Unmarshal(body, commandResponse)
+	if err != nil {
+		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, err)
 	}
 	return commandResponse, nil
 }
 
-func (i *InteractionsClient) DeleteInteractionCommand(guildID string, commandID string) error {
+func (i *InteractionsClient) DeleteInteractionCommand(ctx context.Context, guildID string, commandID string) error {
 	url := `/commands/` + commandID
 	if guildID != "" {
 		url = `/guilds/` + guildID + url
 	}
 
-	response, err := i.makeRequest("DELETE", url, nil)
+	response, err := i.makeRequest(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("DELETE call to %s failed, %w", url, err)
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != 204 {
-		return i.ErrFromResponse(response)
+		body, _ := ioutil.ReadAll(response.Body)
+		return i.ErrFromResponse(response, body)
 	}
 
-	return err
+	return nil
+}
+
+// BulkOverwriteInteractionCommands replaces every command in the scope (global, or guildID) with commands.
+// Commands not in the list are deleted, commands matching an existing name are updated in place.
+func (i *InteractionsClient) BulkOverwriteInteractionCommands(ctx context.Context, guildID string, commands []*InteractionCommand) ([]*InteractionCommand, error) {
+	url := `/commands`
+	if guildID != "" {
+		url = `/guilds/` + guildID + url
+	}
+
+	// nil would be sent as null rather than an empty list
+	if commands == nil {
+		commands = []*InteractionCommand{}
+	}
+
+	response, err := i.makeRequest(ctx, "PUT", url, commands)
+	if err != nil {
+		return nil, fmt.Errorf("PUT call to %s failed: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("response body unavilable, %w", err)
+	}
+
+	if response.StatusCode != 200 {
+		return nil, i.ErrFromResponse(response, body)
+	}
+
+	commandsResponse := []*InteractionCommand{}
+	err = json.Unmarshal(body, &commandsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, err)
+	}
+	return commandsResponse, nil
 }
@@ -0,0 +1,138 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+func TestPatchOnlySendsChangedFields(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/applications/386659935687147521/guilds/386659935687147520/commands/1234" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&sent)
+		if err != nil {
+			t.Errorf("failed to decode body, %v", err)
+		}
+
+		rw.Write([]byte(`{"id": "1234", "name": "renamed"}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewInteractionsClient(client.ClientConfig{
+		ApplicationID: "386659935687147521",
+		BotToken:      "token",
+		APIRoot:       server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client, %v", err)
+	}
+
+	name := "renamed"
+	options := []client.InteractionCommandOption{}
+	command, err := c.PatchInteractionCommand(context.Background(), "386659935687147520", "1234", &client.InteractionCommandPatch{
+		Name:    &name,
+		Options: &options,
+	})
+	if err != nil {
+		t.Fatalf("failed to patch, %v", err)
+	}
+
+	if command.ID != "1234" {
+		t.Errorf("expected ID to be kept, got: %s", command.ID)
+	}
+
+	if len(sent) != 2 || sent["name"] != "renamed" {
+		t.Errorf("expected only name and options to be sent, got: %v", sent)
+	}
+
+	if sentOptions, ok := sent["options"].([]interface{}); !ok || len(sentOptions) != 0 {
+		t.Errorf("expected options to be sent as an empty list, got: %v", sent["options"])
+	}
+}
+
+func TestBulkOverwriteSendsList(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		guildID      string
+		commands     []*client.InteractionCommand
+		expectedPath string
+		expectedBody string
+	}{
+		{
+			desc:         "global",
+			commands:     []*client.InteractionCommand{{Name: "hello-world", Description: "Say hello"}},
+			expectedPath: "/applications/386659935687147521/commands",
+			expectedBody: "[",
+		},
+		{
+			desc:         "guild",
+			guildID:      "386659935687147520",
+			commands:     []*client.InteractionCommand{{Name: "hello-world", Description: "Say hello"}},
+			expectedPath: "/applications/386659935687147521/guilds/386659935687147520/commands",
+			expectedBody: "[",
+		},
+		{
+			desc:         "nil",
+			guildID:      "386659935687147520",
+			expectedPath: "/applications/386659935687147521/guilds/386659935687147520/commands",
+			expectedBody: "[]",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var sent []byte
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				if r.Method != "PUT" || r.URL.Path != tC.expectedPath {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				sent, _ = ioutil.ReadAll(r.Body)
+
+				rw.Write([]byte(`[{"id": "1234", "name": "hello-world"}]`))
+			}))
+			defer server.Close()
+
+			c, err := client.NewInteractionsClient(client.ClientConfig{
+				ApplicationID: "386659935687147521",
+				BotToken:      "token",
+				APIRoot:       server.URL,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client, %v", err)
+			}
+
+			commands, err := c.BulkOverwriteInteractionCommands(context.Background(), tC.guildID, tC.commands)
+			if err != nil {
+				t.Fatalf("failed to overwrite, %v", err)
+			}
+
+			if len(commands) != 1 || commands[0].ID != "1234" {
+				t.Errorf("expected the returned commands, got: %v", commands)
+			}
+
+			body := strings.TrimSpace(string(sent))
+			if !strings.HasPrefix(body, tC.expectedBody) {
+				t.Errorf("expected body to start with %s, got: %s", tC.expectedBody, body)
+			}
+
+			var sentCommands []map[string]interface{}
+			if err := json.Unmarshal(sent, &sentCommands); err != nil {
+				t.Errorf("expected a JSON array, got: %s", body)
+			}
+
+			if len(sentCommands) != len(tC.commands) {
+				t.Errorf("expected %d commands to be sent, got: %s", len(tC.commands), body)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultScopes are requested by the client credentials grant when no scopes are configured.
+var DefaultScopes = []string{
+	"applications.commands.update",
+	"applications.commands.permissions.update",
+}
+
+// tokenRefreshMargin is how long before expiry a cached token is replaced, so it doesn't run out mid-request.
+const tokenRefreshMargin = time.Minute
+
+// tokenSource fetches a token with the OAuth2 client credentials grant, and caches it until shortly before it expires.
+// See: https://discord.com/developers/docs/topics/oauth2#client-credentials-grant
+type tokenSource struct {
+	mu sync.Mutex
+
+	httpClient   *http.Client
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	userAgent    string
+
+	token     string
+	expiresAt time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// Token returns a cached access token, or fetches a new one if there's none or it's about to expire.
+func (t *tokenSource) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Add(tokenRefreshMargin).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {strings.Join(t.scopes, " ")},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", t.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	request.SetBasicAuth(t.clientID, t.clientSecret)
+	request.Header.Set("user-agent", t.userAgent)
+	request.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	response, err := t.httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("POST call to %s failed: %w", t.tokenURL, err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("response body unavilable, %w", err)
+	}
+
+	if response.StatusCode != 200 {
+		return "", fmt.Errorf("client credentials grant failed: %w", newAPIError(response, body))
+	}
+
+	token := tokenResponse{}
+	err = json.Unmarshal(body, &token)
+	if err != nil {
+		return "", fmt.Errorf("JSON parse issue for %s: %w", t.tokenURL, err)
+	}
+
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("client credentials grant did not return an access token")
+	}
+
+	t.token = token.AccessToken
+	t.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return t.token, nil
+}
+
+// invalidate drops token from the cache, so the next call to Token fetches a new one.
+// A token fetched since token was handed out is kept.
+func (t *tokenSource) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
@@ -0,0 +1,209 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+func TestClientCredentialsArePickedPerEndpoint(t *testing.T) {
+	tokenRequests := 0
+	authHeaders := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			tokenRequests++
+
+			clientID, clientSecret, ok := r.BasicAuth()
+			if !ok || clientID != "386659935687147521" || clientSecret != "secret" {
+				t.Errorf("unexpected client credentials: %s:%s", clientID, clientSecret)
+			}
+
+			if r.FormValue("grant_type") != "client_credentials" {
+				t.Errorf("unexpected grant type: %s", r.FormValue("grant_type"))
+			}
+
+			rw.Write([]byte(`{"access_token": "fetched", "token_type": "Bearer", "expires_in": 604800, "scope": "applications.commands.update"}`))
+			return
+		}
+
+		authHeaders[r.Method] = r.Header.Get("authorization")
+
+		if r.Method == "PUT" {
+			rw.Write([]byte(`{"permissions": []}`))
+		} else {
+			rw.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewInteractionsClient(client.ClientConfig{
+		ApplicationID: "386659935687147521",
+		BotToken:      "token",
+		ClientSecret:  "secret",
+		APIRoot:       server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client, %v", err)
+	}
+
+	for n := 0; n < 2; n++ {
+		_, err = c.EditCommandPermissions(context.Background(), "386659935687147520", "1234", nil)
+		if err != nil {
+			t.Fatalf("failed to edit permissions, %v", err)
+		}
+	}
+
+	_, err = c.GetInteractionCommands(context.Background(), "")
+	if err != nil {
+		t.Fatalf("failed to get commands, %v", err)
+	}
+
+	if authHeaders["PUT"] != "Bearer fetched" {
+		t.Errorf("expected permissions to use the fetched token, got: %s", authHeaders["PUT"])
+	}
+
+	if authHeaders["GET"] != "Bot token" {
+		t.Errorf("expected commands to use the bot token, got: %s", authHeaders["GET"])
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("expected the token to be cached, got %d token requests", tokenRequests)
+	}
+}
+
+func TestClientCredentialsAreRefreshed(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		expiresIn      int
+		unauthorized   int
+		expectedTokens int
+		expectedAuth   []string
+		expectsErr     bool
+	}{
+		{
+			desc:           "cached",
+			expiresIn:      604800,
+			expectedTokens: 1,
+			expectedAuth:   []string{"Bearer token-1", "Bearer token-1"},
+		},
+		{
+			desc:           "near expiry",
+			expiresIn:      30,
+			expectedTokens: 2,
+			expectedAuth:   []string{"Bearer token-1", "Bearer token-2"},
+		},
+		{
+			desc:           "revoked",
+			expiresIn:      604800,
+			unauthorized:   1,
+			expectedTokens: 2,
+			expectedAuth:   []string{"Bearer token-1", "Bearer token-2", "Bearer token-2"},
+		},
+		{
+			desc:           "still unauthorized",
+			expiresIn:      604800,
+			unauthorized:   2,
+			expectedTokens: 2,
+			expectedAuth:   []string{"Bearer token-1", "Bearer token-2"},
+			expectsErr:     true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			tokenRequests := 0
+			authHeaders := []string{}
+
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/oauth2/token" {
+					tokenRequests++
+					fmt.Fprintf(rw, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": %d}`, tokenRequests, tC.expiresIn)
+					return
+				}
+
+				authHeaders = append(authHeaders, r.Header.Get("authorization"))
+				if len(authHeaders) <= tC.unauthorized {
+					rw.WriteHeader(http.StatusUnauthorized)
+					rw.Write([]byte(`{"message": "401: Unauthorized", "code": 0}`))
+					return
+				}
+
+				rw.Write([]byte(`{"permissions": []}`))
+			}))
+			defer server.Close()
+
+			c, err := client.NewInteractionsClient(client.ClientConfig{
+				ApplicationID: "386659935687147521",
+				ClientSecret:  "secret",
+				APIRoot:       server.URL,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client, %v", err)
+			}
+
+			for n := 0; n < 2; n++ {
+				_, err = c.EditCommandPermissions(context.Background(), "386659935687147520", "1234", nil)
+				if err != nil {
+					break
+				}
+			}
+
+			if (err != nil) != tC.expectsErr {
+				t.Fatalf("expected error: %v, got: %v", tC.expectsErr, err)
+			}
+
+			if tokenRequests != tC.expectedTokens {
+				t.Errorf("expected %d token requests, got: %d", tC.expectedTokens, tokenRequests)
+			}
+
+			if strings.Join(authHeaders, ", ") != strings.Join(tC.expectedAuth, ", ") {
+				t.Errorf("expected authorization %v, got: %v", tC.expectedAuth, authHeaders)
+			}
+		})
+	}
+}
+
+func TestFailedClientCredentialsGrant(t *testing.T) {
+	apiRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			rw.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+
+		apiRequests++
+		rw.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewInteractionsClient(client.ClientConfig{
+		ApplicationID: "386659935687147521",
+		ClientSecret:  "wrong",
+		APIRoot:       server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client, %v", err)
+	}
+
+	_, err = c.GetInteractionCommands(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "client credentials grant failed") {
+		t.Errorf("expected the grant to fail, got: %v", err)
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected the grant's response to be wrapped, got: %v", err)
+	}
+
+	if apiRequests != 0 {
+		t.Errorf("expected no API requests without a token, got: %d", apiRequests)
+	}
+}
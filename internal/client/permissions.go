@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// GetCommandPermissions gets the permission overwrites for a command in a guild.
+// Discord responds with "Unknown application command permissions" when none were ever set, which is returned as an empty list.
+func (i *InteractionsClient) GetCommandPermissions(ctx context.Context, guildID string, commandID string) (*GuildApplicationCommandPermissions, error) {
+	url := `/guilds/` + guildID + `/commands/` + commandID + `/permissions`
+
+	response, err := i.makeRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GET call to %s failed, %w", url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("response body unavilable, %w", err)
+	}
+
+	if response.StatusCode != 200 {
+		err := i.ErrFromResponse(response, body)
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code == ErrorCodeUnknownApplicationCommandPermissions {
+			return &GuildApplicationCommandPermissions{
+				ID:            commandID,
+				ApplicationID: i.config.ApplicationID,
+				GuildID:       guildID,
+				Permissions:   []ApplicationCommandPermission{},
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	permissions := &GuildApplicationCommandPermissions{}
+	err = json.Unmarshal(body, permissions)
+	if err != nil {
+		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, err)
+	}
+	return permissions, nil
+}
+
+// EditCommandPermissions replaces all permission overwrites for a command in a guild.
+func (i *InteractionsClient) EditCommandPermissions(ctx context.Context, guildID string, commandID string, permissions []ApplicationCommandPermission) (*GuildApplicationCommandPermissions, error) {
+	url := `/guilds/` + guildID + `/commands/` + commandID + `/permissions`
+
+	// nil would be sent as null rather than an empty list
+	if permissions == nil {
+		permissions = []ApplicationCommandPermission{}
+	}
+
+	response, err := i.makeRequest(ctx, "PUT", url, &GuildApplicationCommandPermissions{Permissions: permissions})
+	if err != nil {
+		return nil, fmt.Errorf("PUT call to %s failed: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("response body unavilable, %w", err)
+	}
+
+	if response.StatusCode != 200 {
+		return nil, i.ErrFromResponse(response, body)
+	}
+
+	permissionsResponse := &GuildApplicationCommandPermissions{}
+	err = json.Unmarshal(body, permissionsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("JSON parse issue for %s: %w", url, err)
+	}
+	return permissionsResponse, nil
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRateLimitRetries is how many times a single request may be retried after a 429 before giving up.
+const maxRateLimitRetries = 10
+
+var snowflakeSegmentRegexp = regexp.MustCompile(`^[0-9]+$`)
+
+// rateLimiter tracks Discord's rate limit buckets, as well as the global rate limit.
+// Routes are mapped to buckets by the X-RateLimit-Bucket header, buckets are then split by major parameter (guild ID).
+// See: https://discord.com/developers/docs/topics/rate-limits
+type rateLimiter struct {
+	mu sync.Mutex
+
+	routes      map[string]string
+	buckets     map[string]*rateLimitBucket
+	globalReset time.Time
+
+	// routeResets holds back offs from 429s that didn't name a bucket (e.g. from Cloudflare), by route and major parameter.
+	routeResets map[string]time.Time
+}
+
+type rateLimitBucket struct {
+	remaining int
+	reset     time.Time
+}
+
+type rateLimitResponse struct {
+	RetryAfter float64 `json:"retry_after"`
+	Global     bool    `json:"global"`
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		routes:      map[string]string{},
+		buckets:     map[string]*rateLimitBucket{},
+		routeResets: map[string]time.Time{},
+	}
+}
+
+// routeKey reduces a request to its route, e.g. `GET /guilds/123/commands/:id`.
+// Guild IDs are major parameters so they're kept, every other ID is replaced.
+func routeKey(method, path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] != "guilds" && snowflakeSegmentRegexp.MatchString(segments[i]) {
+			segments[i] = ":id"
+		}
+	}
+
+	return method + " " + strings.Join(segments, "/")
+}
+
+// majorParameter returns the guild ID of a path, if any.
+func majorParameter(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "guilds" {
+			return segments[i]
+		}
+	}
+
+	return ""
+}
+
+func (r *rateLimiter) bucketFor(route, path string) *rateLimitBucket {
+	hash, ok := r.routes[route]
+	if !ok {
+		return nil
+	}
+
+	return r.buckets[hash+":"+majorParameter(path)]
+}
+
+// delay reports how long a request to route must wait before being sent.
+// When no wait is needed, the request's slot in its bucket is reserved.
+func (r *rateLimiter) delay(route, path string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(r.globalReset) {
+		return r.globalReset.Sub(now)
+	}
+
+	if reset := r.routeResets[route+":"+majorParameter(path)]; now.Before(reset) {
+		return reset.Sub(now)
+	}
+
+	bucket := r.bucketFor(route, path)
+	if bucket == nil || now.After(bucket.reset) {
+		return 0
+	}
+
+	if bucket.remaining <= 0 {
+		return bucket.reset.Sub(now)
+	}
+
+	bucket.remaining--
+	return 0
+}
+
+// wait blocks until a request to route may be sent, or ctx is done.
+func (r *rateLimiter) wait(ctx context.Context, route, path string) error {
+	for {
+		delay := r.delay(route, path)
+		if delay <= 0 {
+			return nil
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// update records the rate limit headers of a response.
+// If the response was a 429, it is consumed to find out how long to back off for, then replaced so callers may still read it.
+func (r *rateLimiter) update(route, path string, response *http.Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	header := response.Header
+
+	var bucket *rateLimitBucket
+	if hash := header.Get("X-RateLimit-Bucket"); hash != "" {
+		r.routes[route] = hash
+
+		key := hash + ":" + majorParameter(path)
+		bucket = r.buckets[key]
+		if bucket == nil {
+			bucket = &rateLimitBucket{}
+			r.buckets[key] = bucket
+		}
+
+		if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+			bucket.remaining = remaining
+		}
+
+		if resetAfter, err := strconv.ParseFloat(header.Get("X-RateLimit-Reset-After"), 64); err == nil {
+			bucket.reset = now.Add(secondsToDuration(resetAfter))
+		}
+	}
+
+	if response.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	retryAfter, global := parseRetryAfter(response)
+	if global {
+		r.globalReset = now.Add(retryAfter)
+		return
+	}
+
+	if bucket == nil {
+		r.routeResets[route+":"+majorParameter(path)] = now.Add(retryAfter)
+		return
+	}
+
+	bucket.remaining = 0
+	if reset := now.Add(retryAfter); reset.After(bucket.reset) {
+		bucket.reset = reset
+	}
+}
+
+// parseRetryAfter finds how long a 429 asked us to wait, and whether the global limit was hit.
+// The body's retry_after is preferred as it's more precise than the Retry-After header.
+func parseRetryAfter(response *http.Response) (time.Duration, bool) {
+	global := response.Header.Get("X-RateLimit-Global") == "true"
+
+	retryAfter := time.Second
+	if seconds, err := strconv.ParseFloat(response.Header.Get("Retry-After"), 64); err == nil {
+		retryAfter = secondsToDuration(seconds)
+	}
+
+	if response.Body == nil {
+		return retryAfter, global
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return retryAfter, global
+	}
+
+	rateLimit := rateLimitResponse{}
+	if err := json.Unmarshal(body, &rateLimit); err == nil {
+		if rateLimit.RetryAfter > 0 {
+			retryAfter = secondsToDuration(rateLimit.RetryAfter)
+		}
+		global = global || rateLimit.Global
+	}
+
+	return retryAfter, global
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
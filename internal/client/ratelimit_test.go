@@ -0,0 +1,192 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+func TestRateLimitedRequestIsRetried(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		rateLimit   func(rw http.ResponseWriter)
+		minimumWait time.Duration
+	}{
+		{
+			desc: "bucket",
+			rateLimit: func(rw http.ResponseWriter) {
+				rw.Header().Set("X-RateLimit-Bucket", "abcd1234")
+				rw.Header().Set("X-RateLimit-Remaining", "0")
+				rw.Header().Set("X-RateLimit-Reset-After", "0.05")
+				rw.Header().Set("Retry-After", "1")
+				rw.WriteHeader(http.StatusTooManyRequests)
+				rw.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.05, "global": false}`))
+			},
+			minimumWait: 50 * time.Millisecond,
+		},
+		{
+			desc: "only Retry-After",
+			rateLimit: func(rw http.ResponseWriter) {
+				rw.Header().Set("Retry-After", "0.2")
+				rw.Header().Set("Content-Type", "text/html")
+				rw.WriteHeader(http.StatusTooManyRequests)
+				rw.Write([]byte(`<html><body>Too many requests</body></html>`))
+			},
+			minimumWait: 200 * time.Millisecond,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				calls++
+
+				if calls == 1 {
+					tC.rateLimit(rw)
+					return
+				}
+
+				rw.Header().Set("X-RateLimit-Bucket", "abcd1234")
+				rw.Header().Set("X-RateLimit-Remaining", "4")
+				rw.Header().Set("X-RateLimit-Reset-After", "1")
+				rw.Write([]byte(`{"id": "1234", "name": "hello-world"}`))
+			}))
+			defer server.Close()
+
+			c, err := client.NewInteractionsClient(client.ClientConfig{
+				ApplicationID: "386659935687147521",
+				BotToken:      "token",
+				APIRoot:       server.URL,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client, %v", err)
+			}
+
+			start := time.Now()
+			command, err := c.GetInteractionCommand(context.Background(), "", "1234")
+			elapsed := time.Since(start)
+			if err != nil {
+				t.Fatalf("expected rate limited request to succeed after waiting, got: %v", err)
+			}
+
+			if command.Name != "hello-world" {
+				t.Errorf("unexpected command, got: %v", command)
+			}
+
+			if calls != 2 {
+				t.Errorf("expected 2 calls, got: %d", calls)
+			}
+
+			if elapsed < tC.minimumWait {
+				t.Errorf("expected to wait at least %s before retrying, waited: %s", tC.minimumWait, elapsed)
+			}
+		})
+	}
+}
+
+func TestRequestsWaitForRateLimits(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		limit      func(rw http.ResponseWriter)
+		otherRoute bool
+		wait       time.Duration
+	}{
+		{
+			desc: "bucket exhausted",
+			limit: func(rw http.ResponseWriter) {
+				rw.Header().Set("X-RateLimit-Bucket", "abcd1234")
+				rw.Header().Set("X-RateLimit-Remaining", "0")
+				rw.Header().Set("X-RateLimit-Reset-After", "0.2")
+				rw.Write([]byte(`{"id": "1234", "name": "hello-world"}`))
+			},
+			wait: 200 * time.Millisecond,
+		},
+		{
+			desc: "global header",
+			limit: func(rw http.ResponseWriter) {
+				rw.Header().Set("X-RateLimit-Global", "true")
+				rw.Header().Set("Retry-After", "0.2")
+				rw.WriteHeader(http.StatusTooManyRequests)
+				rw.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.2}`))
+			},
+			otherRoute: true,
+			wait:       200 * time.Millisecond,
+		},
+		{
+			desc: "global body",
+			limit: func(rw http.ResponseWriter) {
+				rw.WriteHeader(http.StatusTooManyRequests)
+				rw.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.2, "global": true}`))
+			},
+			otherRoute: true,
+			wait:       200 * time.Millisecond,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var limitedUntil time.Time
+			calls, early := 0, 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				calls++
+
+				if calls == 1 {
+					limitedUntil = time.Now().Add(tC.wait)
+					tC.limit(rw)
+					return
+				}
+
+				if time.Now().Before(limitedUntil) {
+					early++
+					rw.Header().Set("Retry-After", "0.2")
+					rw.WriteHeader(http.StatusTooManyRequests)
+					rw.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.2}`))
+					return
+				}
+
+				if strings.HasSuffix(r.URL.Path, "/commands") {
+					rw.Write([]byte(`[]`))
+				} else {
+					rw.Write([]byte(`{"id": "1234", "name": "hello-world"}`))
+				}
+			}))
+			defer server.Close()
+
+			c, err := client.NewInteractionsClient(client.ClientConfig{
+				ApplicationID: "386659935687147521",
+				BotToken:      "token",
+				APIRoot:       server.URL,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client, %v", err)
+			}
+
+			// The first request only needs to see the rate limit, it's given up on rather than retried.
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			c.GetInteractionCommand(ctx, "", "1234")
+			cancel()
+
+			if tC.otherRoute {
+				_, err = c.GetInteractionCommands(context.Background(), "386659935687147520")
+			} else {
+				_, err = c.GetInteractionCommand(context.Background(), "", "1234")
+			}
+			if err != nil {
+				t.Fatalf("expected the request to succeed after waiting, got: %v", err)
+			}
+
+			if early != 0 {
+				t.Errorf("expected to wait until the rate limit reset, got %d early requests", early)
+			}
+
+			if calls != 2 {
+				t.Errorf("expected 2 calls, got: %d", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMinRetryBackoff = time.Second
+	defaultMaxRetryBackoff = time.Second * 30
+)
+
+// retryableStatusCodes are responses from Discord (or Cloudflare in front of it) that are likely to go away on their own.
+var retryableStatusCodes = map[int]bool{
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// shouldRetry decides if a request is worth trying again based on its outcome.
+func shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return isRetryableError(err)
+	}
+
+	return retryableStatusCodes[response.StatusCode]
+}
+
+// isRetryableError matches timeouts and connections that were refused, reset or dropped.
+func isRetryableError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// retryBackoff is an exponential backoff from MinRetryBackoff, capped at MaxRetryBackoff.
+// The upper half of each step is jittered so parallel applies don't retry in lockstep.
+func (i *InteractionsClient) retryBackoff(attempt int) time.Duration {
+	backoff := i.config.MinRetryBackoff
+	for n := 0; n < attempt && backoff < i.config.MaxRetryBackoff; n++ {
+		backoff *= 2
+	}
+
+	if backoff > i.config.MaxRetryBackoff {
+		backoff = i.config.MaxRetryBackoff
+	}
+
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// sleep waits for d, returning early with the context's error if it's cancelled or times out first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// discardResponse drains and closes a response that won't be handed back to the caller, so the connection can be reused.
+func discardResponse(response *http.Response) {
+	if response == nil || response.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+}
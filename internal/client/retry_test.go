@@ -0,0 +1,146 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+func TestTransientFailuresAreRetried(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		maxRetries    int
+		failures      int
+		expectSuccess bool
+		expectCalls   int
+	}{
+		{
+			desc:          "recovers",
+			maxRetries:    3,
+			failures:      2,
+			expectSuccess: true,
+			expectCalls:   3,
+		},
+		{
+			desc:          "gives up",
+			maxRetries:    1,
+			failures:      2,
+			expectSuccess: false,
+			expectCalls:   2,
+		},
+		{
+			desc:          "disabled",
+			maxRetries:    0,
+			failures:      1,
+			expectSuccess: false,
+			expectCalls:   1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				calls++
+
+				if calls <= tC.failures {
+					rw.WriteHeader(http.StatusBadGateway)
+					return
+				}
+
+				rw.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			c, err := client.NewInteractionsClient(client.ClientConfig{
+				ApplicationID:   "386659935687147521",
+				BotToken:        "token",
+				APIRoot:         server.URL,
+				MaxRetries:      tC.maxRetries,
+				MinRetryBackoff: time.Millisecond,
+				MaxRetryBackoff: time.Millisecond * 5,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client, %v", err)
+			}
+
+			err = c.DeleteInteractionCommand(context.Background(), "", "1234")
+			if (err == nil) != tC.expectSuccess {
+				t.Errorf("did not match expectation, got: %v", err)
+			}
+
+			if calls != tC.expectCalls {
+				t.Errorf("expected %d calls, got: %d", tC.expectCalls, calls)
+			}
+		})
+	}
+}
+
+func TestCancelWhileWaiting(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		respond func(rw http.ResponseWriter)
+	}{
+		{
+			desc: "rate limit wait",
+			respond: func(rw http.ResponseWriter) {
+				rw.Header().Set("X-RateLimit-Bucket", "abcd1234")
+				rw.Header().Set("X-RateLimit-Remaining", "0")
+				rw.Header().Set("X-RateLimit-Reset-After", "30")
+				rw.WriteHeader(http.StatusTooManyRequests)
+				rw.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 30, "global": false}`))
+			},
+		},
+		{
+			desc: "retry backoff",
+			respond: func(rw http.ResponseWriter) {
+				rw.WriteHeader(http.StatusBadGateway)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				calls++
+				tC.respond(rw)
+			}))
+			defer server.Close()
+
+			c, err := client.NewInteractionsClient(client.ClientConfig{
+				ApplicationID:   "386659935687147521",
+				BotToken:        "token",
+				APIRoot:         server.URL,
+				MaxRetries:      3,
+				MinRetryBackoff: 30 * time.Second,
+				MaxRetryBackoff: 30 * time.Second,
+			})
+			if err != nil {
+				t.Fatalf("failed to create client, %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			start := time.Now()
+			_, err = c.GetInteractionCommand(ctx, "", "1234")
+			elapsed := time.Since(start)
+
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context.Canceled, got: %v", err)
+			}
+
+			if elapsed > time.Second {
+				t.Errorf("expected to return promptly after cancelling, took: %s", elapsed)
+			}
+
+			if calls != 1 {
+				t.Errorf("expected no requests after cancelling, got %d calls", calls)
+			}
+		})
+	}
+}
@@ -1,29 +1,155 @@
 package client
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 type InteractionCommand struct {
-	ID                string `json:"id,omitempty"`
-	ApplicationID     string `json:"application_id,omitempty"`
-	GuildID           string `json:"guild_id,omitempty"`
-	Name              string `json:"name,omitempty"`
-	Description       string `json:"description,omitempty"`
-	DefaultPermission bool   `json:"default_permission,omitempty"`
+	ID            string `json:"id,omitempty"`
+	Type          int    `json:"type,omitempty"`
+	ApplicationID string `json:"application_id,omitempty"`
+	GuildID       string `json:"guild_id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+
+	// NameLocalizations and DescriptionLocalizations map a locale, like `pt-BR`, to the name or description shown to users of that locale.
+	NameLocalizations        map[string]string `json:"name_localizations,omitempty"`
+	DescriptionLocalizations map[string]string `json:"description_localizations,omitempty"`
+
+	// DefaultPermission is a pointer so false is sent, nil means Discord's default of true.
+	DefaultPermission *bool `json:"default_permission,omitempty"`
 
 	Options []InteractionCommandOption `json:"options,omitempty"`
 }
 
+// InteractionCommandPatch is a partial update to a command. Only non-nil fields are sent, so unchanged fields are left alone.
+type InteractionCommandPatch struct {
+	Name              *string `json:"name,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	DefaultPermission *bool   `json:"default_permission,omitempty"`
+
+	// Localizations and Options are pointers so an empty map or list can be sent to remove every entry.
+	NameLocalizations        *map[string]string          `json:"name_localizations,omitempty"`
+	DescriptionLocalizations *map[string]string          `json:"description_localizations,omitempty"`
+	Options                  *[]InteractionCommandOption `json:"options,omitempty"`
+}
+
 type InteractionCommandOption struct {
 	Type        int    `json:"type,omitempty"`
 	Name        string `json:"name,omitempty"`
 	Description string `json:"description,omitempty"`
-	Required    bool   `json:"required,omitempty"`
+
+	NameLocalizations        map[string]string `json:"name_localizations,omitempty"`
+	DescriptionLocalizations map[string]string `json:"description_localizations,omitempty"`
+
+	// Required is a pointer so false is sent, nil means Discord's default of false.
+	Required *bool `json:"required,omitempty"`
 
 	Choices []InteractionCommandOptionChoice `json:"choices,omitempty"`
 	Options []InteractionCommandOption       `json:"options,omitempty"`
+
+	// ChannelTypes limits the channels a CHANNEL option offers, see the ChannelType constants.
+	ChannelTypes []int `json:"channel_types,omitempty"`
+
+	// MinValue and MaxValue limit INTEGER and NUMBER options, MinLength and MaxLength limit STRING options.
+	// They're pointers so an explicit 0 is sent, nil means no limit.
+	MinValue  *float64 `json:"min_value,omitempty"`
+	MaxValue  *float64 `json:"max_value,omitempty"`
+	MinLength *int     `json:"min_length,omitempty"`
+	MaxLength *int     `json:"max_length,omitempty"`
 }
 
 type InteractionCommandOptionChoice struct {
-	Name string `json:"name,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	NameLocalizations map[string]string `json:"name_localizations,omitempty"`
 
-	// Value can be a string, int, or float
+	// Value can be a string, int, or float. When read from Discord, numbers are a json.Number, so integers aren't turned into floats.
 	Value interface{} `json:"value,omitempty"`
 }
+
+func (c *InteractionCommandOptionChoice) UnmarshalJSON(data []byte) error {
+	// Alias drops the UnmarshalJSON method, so this doesn't recurse.
+	type choice InteractionCommandOptionChoice
+	decoded := choice{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return err
+	}
+
+	*c = InteractionCommandOptionChoice(decoded)
+	return nil
+}
+
+// Bool returns a pointer to value, for optional fields where false is meaningful.
+func Bool(value bool) *bool {
+	return &value
+}
+
+// Command types, see: https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-types
+const (
+	CommandTypeChatInput = 1
+	CommandTypeUser      = 2
+	CommandTypeMessage   = 3
+)
+
+// Channel types, see: https://discord.com/developers/docs/resources/channel#channel-object-channel-types
+const (
+	ChannelTypeGuildText          = 0
+	ChannelTypeDM                 = 1
+	ChannelTypeGuildVoice         = 2
+	ChannelTypeGroupDM            = 3
+	ChannelTypeGuildCategory      = 4
+	ChannelTypeGuildAnnouncement  = 5
+	ChannelTypeAnnouncementThread = 10
+	ChannelTypePublicThread       = 11
+	ChannelTypePrivateThread      = 12
+	ChannelTypeGuildStageVoice    = 13
+	ChannelTypeGuildDirectory     = 14
+	ChannelTypeGuildForum         = 15
+	ChannelTypeGuildMedia         = 16
+)
+
+// Option types, see: https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-type
+const (
+	OptionTypeSubCommand      = 1
+	OptionTypeSubCommandGroup = 2
+	OptionTypeString          = 3
+	OptionTypeInteger         = 4
+	OptionTypeBoolean         = 5
+	OptionTypeUser            = 6
+	OptionTypeChannel         = 7
+	OptionTypeRole            = 8
+	OptionTypeMentionable     = 9
+	OptionTypeNumber          = 10
+	OptionTypeAttachment      = 11
+)
+
+// GuildApplicationCommandPermissions are the permission overwrites for a command in a guild.
+type GuildApplicationCommandPermissions struct {
+	ID            string `json:"id,omitempty"`
+	ApplicationID string `json:"application_id,omitempty"`
+	GuildID       string `json:"guild_id,omitempty"`
+
+	Permissions []ApplicationCommandPermission `json:"permissions"`
+}
+
+type ApplicationCommandPermission struct {
+	// ID of the role, user or channel. The guild ID means @everyone, and the guild ID - 1 means all channels.
+	ID   string `json:"id"`
+	Type int    `json:"type"`
+
+	// Permission is true to allow, false to deny. Not omitempty, as false is meaningful.
+	Permission bool `json:"permission"`
+}
+
+// Permission types, see: https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-application-command-permission-type
+const (
+	PermissionTypeRole    = 1
+	PermissionTypeUser    = 2
+	PermissionTypeChannel = 3
+)
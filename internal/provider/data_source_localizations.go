@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLocalizations() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads localizations from a directory of translation files, one per locale, named like `pt-BR.json`, `ja.yaml` or `de.po`. " +
+			"Keys are paths to what they localize, like `config.set.value.description`, which JSON and YAML files may nest as objects. " +
+			"Missing keys, unknown locales and text over Discord's length limits are reported as warnings.",
+		ReadContext: dataSourceLocalizationsRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Description: "Directory holding the translation files",
+				Required:    true,
+			},
+			"locales": {
+				Type:        schema.TypeList,
+				Description: "Locales read from the directory",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"localizations": {
+				Type: schema.TypeMap,
+				Description: "Map of key to its localizations, as a JSON-encoded map of locale to localized text. " +
+					"Use `jsondecode(data.discord-interactions_localizations.example.localizations[\"config.name\"])` for the `name_localizations` or `description_localizations` arguments.",
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"localization": {
+				Type: schema.TypeList,
+				Description: "Localizations of each key, sorted by key. " +
+					"A map like `localizations` can be built with `{ for l in data.discord-interactions_localizations.example.localization : l.key => l.values }`.",
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Description: "Path of what's localized, like `config.set.value.description`",
+							Computed:    true,
+						},
+						"values": {
+							Type:        schema.TypeMap,
+							Description: "Map of locale to localized text, ready for the `name_localizations` or `description_localizations` arguments",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLocalizationsRead(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	path := resource.Get("path").(string)
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	translations := map[string]map[string]string{}
+	filenames := map[string]string{}
+
+	for _, file := range files {
+		extension := filepath.Ext(file.Name())
+		if file.IsDir() || !isLocalizationFile(extension) {
+			continue
+		}
+
+		locale := strings.TrimSuffix(file.Name(), extension)
+		if !transforms.IsLocale(locale) {
+			diags = append(diags, localizationWarning(fmt.Sprintf("%s is not named after a locale supported by Discord, so it was skipped. Supported locales are: %s", file.Name(), strings.Join(transforms.Locales, ", "))))
+			continue
+		}
+
+		if previous, ok := filenames[locale]; ok {
+			return append(diags, diag.Errorf("%s and %s both hold localizations for %s, only one file per locale is allowed", previous, file.Name(), locale)...)
+		}
+		filenames[locale] = file.Name()
+
+		data, err := ioutil.ReadFile(filepath.Join(path, file.Name()))
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		localizations, err := transforms.ParseLocalizationFile(file.Name(), data)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		translations[locale] = localizations
+	}
+
+	for _, warning := range transforms.LocalizationWarnings(translations) {
+		diags = append(diags, localizationWarning(warning))
+	}
+
+	locales := make([]string, 0, len(translations))
+	byKey := map[string]map[string]interface{}{}
+	for locale, localizations := range translations {
+		locales = append(locales, locale)
+
+		for key, value := range localizations {
+			if byKey[key] == nil {
+				byKey[key] = map[string]interface{}{}
+			}
+
+			byKey[key][locale] = value
+		}
+	}
+	sort.Strings(locales)
+
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	localizationItems := make([]interface{}, len(keys))
+	localizationsByKey := make(map[string]interface{}, len(keys))
+	for i, key := range keys {
+		localizationItems[i] = map[string]interface{}{
+			"key":    key,
+			"values": byKey[key],
+		}
+
+		// Map values must all be of one type, so each locale map is encoded.
+		encoded, err := json.Marshal(byKey[key])
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		localizationsByKey[key] = string(encoded)
+	}
+
+	err = resource.Set("locales", locales)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	err = resource.Set("localization", localizationItems)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	err = resource.Set("localizations", localizationsByKey)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	resource.SetId(path)
+
+	return diags
+}
+
+func isLocalizationFile(extension string) bool {
+	for _, known := range transforms.LocalizationFileExtensions {
+		if strings.EqualFold(extension, known) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func localizationWarning(detail string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Warning,
+		Summary:       "Problem with localizations",
+		Detail:        detail,
+		AttributePath: cty.GetAttrPath("path"),
+	}
+}
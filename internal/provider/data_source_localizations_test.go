@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLocalizationsByKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "localizations")
+	if err != nil {
+		t.Fatalf("failed to create directory, %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"pt-BR.json": `{"config": {"name": "configuração", "description": "Muda as configurações"}}`,
+		"de.yaml":    "config:\n  name: einstellungen\n",
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("failed to write %s, %v", name, err)
+		}
+	}
+
+	resource := schema.TestResourceDataRaw(t, dataSourceLocalizations().Schema, map[string]interface{}{
+		"path": dir,
+	})
+
+	diags := dataSourceLocalizationsRead(context.Background(), resource, nil)
+	if diags.HasError() {
+		t.Fatalf("failed to read, %v", diags)
+	}
+
+	localizations := resource.Get("localizations").(map[string]interface{})
+	testCases := []struct {
+		key      string
+		expected map[string]string
+	}{
+		{
+			key:      "config.name",
+			expected: map[string]string{"de": "einstellungen", "pt-BR": "configuração"},
+		},
+		{
+			key:      "config.description",
+			expected: map[string]string{"pt-BR": "Muda as configurações"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.key, func(t *testing.T) {
+			encoded, ok := localizations[tC.key].(string)
+			if !ok {
+				t.Fatalf("expected %s in localizations, got: %v", tC.key, localizations)
+			}
+
+			decoded := map[string]string{}
+			err := json.Unmarshal([]byte(encoded), &decoded)
+			if err != nil {
+				t.Fatalf("expected a JSON-encoded map, got: %s", encoded)
+			}
+
+			if len(decoded) != len(tC.expected) {
+				t.Errorf("expected %v, got: %v", tC.expected, decoded)
+			}
+
+			for locale, value := range tC.expected {
+				if decoded[locale] != value {
+					t.Errorf("expected %s to be %q, got: %q", locale, value, decoded[locale])
+				}
+			}
+		})
+	}
+}
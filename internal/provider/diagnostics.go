@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+)
+
+// commandFieldAttributes maps Discord's command JSON fields to their schema attribute, where they aren't the same.
+var commandFieldAttributes = map[string]string{
+	"options": "option",
+	"choices": "choice",
+}
+
+// commandErrorDiagnostics turns an error from sending a command into diagnostics.
+// Discord's field errors get a diagnostic each, pointed at the attribute they're about, relative to basePath.
+// optionItems is the configured `option` list, used to find the type of the option holding a choice.
+func commandErrorDiagnostics(err error, basePath cty.Path, optionItems []interface{}) diag.Diagnostics {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, fieldError := range apiErr.Errors {
+		diags = append(diags, fieldErrorDiagnostic(apiErr, fieldError, commandFieldPath(basePath, fieldError.Path, optionItems)))
+	}
+
+	return diags
+}
+
+// commandSetErrorDiagnostics is commandErrorDiagnostics for a bulk overwrite, where field paths start with the index of the command, like `3.options.0.name`
+func commandSetErrorDiagnostics(err error, commandItems []interface{}) diag.Diagnostics {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, fieldError := range apiErr.Errors {
+		path := cty.GetAttrPath("command")
+
+		segments := strings.SplitN(fieldError.Path, ".", 2)
+		index, err := strconv.Atoi(segments[0])
+		if err == nil && index >= 0 && index < len(commandItems) {
+			path = path.IndexInt(index)
+
+			if len(segments) == 2 {
+				commandItem, _ := commandItems[index].(map[string]interface{})
+				optionItems, _ := commandItem["option"].([]interface{})
+				path = commandFieldPath(path, segments[1], optionItems)
+			}
+		}
+
+		diags = append(diags, fieldErrorDiagnostic(apiErr, fieldError, path))
+	}
+
+	return diags
+}
+
+// commandSizeDiagnostics warns if command is close to Discord's size limit, see transforms.CommandSizeWarning.
+func commandSizeDiagnostics(command *client.InteractionCommand, path cty.Path) diag.Diagnostics {
+	warning := transforms.CommandSizeWarning(command)
+	if warning == "" {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "Command is close to Discord's size limit",
+		Detail:        warning,
+		AttributePath: path,
+	}}
+}
+
+func fieldErrorDiagnostic(apiErr *client.APIError, fieldError client.FieldError, path cty.Path) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("Discord rejected the command: %s", apiErr.Message),
+		Detail:        fmt.Sprintf("%s: %s (%s)", fieldError.Path, fieldError.Message, fieldError.Code),
+		AttributePath: path,
+	}
+}
+
+// commandFieldPath translates a Discord field path like `options.2.choices.0.value` into an attribute path like `option.1.choice.0.string_value`.
+// If part of the path can't be translated, the path up to that point is returned.
+func commandFieldPath(basePath cty.Path, fieldPath string, optionItems []interface{}) cty.Path {
+	path := basePath.Copy()
+	segments := strings.Split(fieldPath, ".")
+
+	var option map[string]interface{}
+	for i := 0; i < len(segments); i++ {
+		field := segments[i]
+
+		switch field {
+		case "options", "choices":
+			if i+1 >= len(segments) {
+				return path
+			}
+
+			index, err := strconv.Atoi(segments[i+1])
+			if err != nil {
+				return path
+			}
+
+			if field == "options" {
+				if index < 0 || index >= len(optionItems) {
+					return path
+				}
+
+				option, _ = optionItems[index].(map[string]interface{})
+				optionItems, _ = option["option"].([]interface{})
+			} else if option == nil {
+				return path
+			}
+
+			path = path.GetAttr(commandFieldAttributes[field]).IndexInt(index)
+			i++
+		case "value":
+			return path.GetAttr(choiceValueAttribute(option))
+		case "name", "description", "type", "required", "default_permission":
+			return path.GetAttr(field)
+		case "name_localizations", "description_localizations":
+			path = path.GetAttr(field)
+			if i+1 < len(segments) {
+				path = path.Index(cty.StringVal(segments[i+1]))
+			}
+
+			return path
+		default:
+			return path
+		}
+	}
+
+	return path
+}
+
+// choiceValueAttribute picks which choice value attribute is used, based on the type of the option holding it.
+func choiceValueAttribute(option map[string]interface{}) string {
+	optionType, _ := option["type"].(int)
+
+	switch optionType {
+	case client.OptionTypeInteger:
+		return "int_value"
+	case client.OptionTypeNumber:
+		return "float_value"
+	default:
+		return "string_value"
+	}
+}
+
+// validationError combines plan time validation errors into one, or nil if there are none.
+func validationError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return multierror.Append(nil, errs...)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestCommandFieldPath(t *testing.T) {
+	optionItems := []interface{}{
+		map[string]interface{}{
+			"type":     4,
+			"name":     "required",
+			"required": true,
+			"choice": []interface{}{
+				map[string]interface{}{"name": "one", "int_value": 1},
+			},
+		},
+		map[string]interface{}{
+			"type":     3,
+			"name":     "optional",
+			"required": false,
+			"choice":   []interface{}{},
+		},
+	}
+
+	testCases := []struct {
+		fieldPath string
+		expected  cty.Path
+	}{
+		{
+			fieldPath: "name",
+			expected:  cty.GetAttrPath("name"),
+		},
+		{
+			fieldPath: "options.0.choices.0.value",
+			expected:  cty.GetAttrPath("option").IndexInt(0).GetAttr("choice").IndexInt(0).GetAttr("int_value"),
+		},
+		{
+			fieldPath: "options.1.description",
+			expected:  cty.GetAttrPath("option").IndexInt(1).GetAttr("description"),
+		},
+		{
+			fieldPath: "options.1.name_localizations.pt-BR",
+			expected:  cty.GetAttrPath("option").IndexInt(1).GetAttr("name_localizations").Index(cty.StringVal("pt-BR")),
+		},
+		{
+			fieldPath: "options.5.name",
+			expected:  cty.Path{},
+		},
+		{
+			fieldPath: "options.1.something_new",
+			expected:  cty.GetAttrPath("option").IndexInt(1),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.fieldPath, func(t *testing.T) {
+			path := commandFieldPath(cty.Path{}, tC.fieldPath, optionItems)
+
+			if !path.Equals(tC.expected) {
+				t.Errorf("did not match expectation, got: %#v, wanted: %#v", path, tC.expected)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
 	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
 )
@@ -15,11 +17,15 @@ func New(version string) func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			ResourcesMap: map[string]*schema.Resource{
-				"discord-interactions_global_command": resourceGlobalCommand(),
-				"discord-interactions_guild_command":  resourceGuildCommand(),
-				// TODO: add permissions
+				"discord-interactions_global_command":                resourceGlobalCommand(),
+				"discord-interactions_guild_command":                 resourceGuildCommand(),
+				"discord-interactions_command_set":                   resourceCommandSet(),
+				"discord-interactions_command_permissions":           resourceCommandPermissions(),
+				"discord-interactions_application_guild_permissions": resourceApplicationGuildPermissions(),
+			},
+			DataSourcesMap: map[string]*schema.Resource{
+				"discord-interactions_localizations": dataSourceLocalizations(),
 			},
-			DataSourcesMap: map[string]*schema.Resource{},
 			Schema: map[string]*schema.Schema{
 				"application_id": {
 					Type:         schema.TypeString,
@@ -32,17 +38,63 @@ func New(version string) func() *schema.Provider {
 					Type:         schema.TypeString,
 					Sensitive:    true,
 					Optional:     true,
-					ExactlyOneOf: []string{"client_credentials_token", "bot_token"},
+					AtLeastOneOf: []string{"client_credentials_token", "bot_token", "client_secret"},
 					Description:  "Discord bot token from https://discord.com/developers. Defaults to environment variable `DISCORD_BOT_TOKEN`",
 					DefaultFunc:  schema.EnvDefaultFunc("DISCORD_BOT_TOKEN", nil),
 				},
 				"client_credentials_token": {
+					Type:          schema.TypeString,
+					Sensitive:     true,
+					Optional:      true,
+					AtLeastOneOf:  []string{"client_credentials_token", "bot_token", "client_secret"},
+					ConflictsWith: []string{"client_secret"},
+					Description:   "Discord client credentials token. (must have applications.commands.update scope). Defaults to environment variable `DISCORD_CLIENT_TOKEN`",
+					DefaultFunc:   schema.EnvDefaultFunc("DISCORD_CLIENT_TOKEN", nil),
+				},
+				"client_id": {
 					Type:         schema.TypeString,
-					Sensitive:    true,
 					Optional:     true,
-					ExactlyOneOf: []string{"client_credentials_token", "bot_token"},
-					Description:  "Discord client credentials token. (must have applications.commands.update scope). Defaults to environment variable `DISCORD_CLIENT_TOKEN`",
-					DefaultFunc:  schema.EnvDefaultFunc("DISCORD_CLIENT_TOKEN", nil),
+					Description:  "OAuth2 client ID, used with `client_secret`. Defaults to environment variable `DISCORD_CLIENT_ID`, or `application_id`",
+					DefaultFunc:  schema.EnvDefaultFunc("DISCORD_CLIENT_ID", nil),
+					ValidateFunc: transforms.ValidateSnowflake,
+				},
+				"client_secret": {
+					Type:          schema.TypeString,
+					Sensitive:     true,
+					Optional:      true,
+					AtLeastOneOf:  []string{"client_credentials_token", "bot_token", "client_secret"},
+					ConflictsWith: []string{"client_credentials_token"},
+					Description:   "OAuth2 client secret from https://discord.com/developers. Used to fetch and refresh a client credentials token, which is required to manage permissions. Can be combined with `bot_token`. Defaults to environment variable `DISCORD_CLIENT_SECRET`",
+					DefaultFunc:   schema.EnvDefaultFunc("DISCORD_CLIENT_SECRET", nil),
+				},
+				"scopes": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "OAuth2 scopes to request with `client_secret`. Defaults to `applications.commands.update` and `applications.commands.permissions.update`",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      3,
+					Description:  "How many times to retry a Discord API call that failed with a 500, 502, 503, 504 or network error. Set to 0 to disable retries.",
+					ValidateFunc: validation.IntBetween(0, 20),
+				},
+				"retry_min_backoff": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "1s",
+					Description:  "Delay before the first retry, as a duration like `500ms` or `2s`. Doubles with every following retry, plus jitter.",
+					ValidateFunc: transforms.ValidateDuration,
+				},
+				"retry_max_backoff": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "30s",
+					Description:  "Maximum delay between retries, as a duration like `30s` or `1m`.",
+					ValidateFunc: transforms.ValidateDuration,
 				},
 				"api_root": {
 					Type:        schema.TypeString,
@@ -81,14 +133,32 @@ func configureProvider(version string, provider *schema.Provider) func(context.C
 		applicationID := d.Get("application_id").(string)
 		botToken := d.Get("bot_token").(string)
 		clientCredentials := d.Get("client_credentials_token").(string)
+		clientID := d.Get("client_id").(string)
+		clientSecret := d.Get("client_secret").(string)
+
+		scopes := []string{}
+		for _, scope := range d.Get("scopes").([]interface{}) {
+			scopes = append(scopes, scope.(string))
+		}
 		apiRoot := d.Get("api_root").(string)
+		maxRetries := d.Get("max_retries").(int)
+
+		// Both were validated by transforms.ValidateDuration
+		minRetryBackoff, _ := time.ParseDuration(d.Get("retry_min_backoff").(string))
+		maxRetryBackoff, _ := time.ParseDuration(d.Get("retry_max_backoff").(string))
 
 		client, err := client.NewInteractionsClient(client.ClientConfig{
 			ApplicationID:     applicationID,
 			BotToken:          botToken,
 			ClientCredentials: clientCredentials,
+			ClientID:          clientID,
+			ClientSecret:      clientSecret,
+			Scopes:            scopes,
 			APIRoot:           apiRoot,
 			UserAgent:         userAgent,
+			MaxRetries:        maxRetries,
+			MinRetryBackoff:   minRetryBackoff,
+			MaxRetryBackoff:   maxRetryBackoff,
 		})
 
 		if err != nil {
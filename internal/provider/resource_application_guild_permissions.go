@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceApplicationGuildPermissions manages the overwrites Discord applies to every command of the application in a guild.
+// These use the command permissions endpoints, with the application ID in place of a command ID.
+func resourceApplicationGuildPermissions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages application-wide permission overwrites in a guild, applying to every command unless a command has its own overwrite. " +
+			"Overwrites not declared here are removed. " +
+			"Discord stores these apart from the overwrites of each command, so this can be used alongside `discord-interactions_command_permissions` in the same guild: " +
+			"a command's own overwrite for a role, user or channel wins over the application-wide one for that target. " +
+			"The two would only conflict if `discord-interactions_command_permissions` were given the application ID as its `command_id`, which it rejects at plan time. " +
+			"Declare at most one of these per guild, as they would otherwise remove each other's overwrites.",
+		CreateContext: resourceApplicationGuildPermissionsCreate,
+		ReadContext:   resourceApplicationGuildPermissionsRead,
+		UpdateContext: resourceApplicationGuildPermissionsUpdate,
+		DeleteContext: resourceApplicationGuildPermissionsDelete,
+		CustomizeDiff: permissionsCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"guild_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: transforms.ValidateSnowflake,
+			},
+			"permission": permissionsSchema(),
+		},
+	}
+}
+
+func resourceApplicationGuildPermissionsCreate(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.InteractionsClient)
+	guildID := resource.Get("guild_id").(string)
+
+	diags := editPermissions(ctx, resource, m, guildID, c.ApplicationID())
+	if diags.HasError() {
+		return diags
+	}
+
+	resource.SetId(guildID)
+
+	return resourceApplicationGuildPermissionsRead(ctx, resource, m)
+}
+
+func resourceApplicationGuildPermissionsRead(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.InteractionsClient)
+
+	return readPermissions(ctx, resource, m, resource.Id(), c.ApplicationID())
+}
+
+func resourceApplicationGuildPermissionsUpdate(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.InteractionsClient)
+
+	diags := editPermissions(ctx, resource, m, resource.Id(), c.ApplicationID())
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceApplicationGuildPermissionsRead(ctx, resource, m)
+}
+
+func resourceApplicationGuildPermissionsDelete(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.InteractionsClient)
+
+	return clearPermissions(ctx, m, resource.Id(), c.ApplicationID())
+}
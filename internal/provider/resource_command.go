@@ -2,66 +2,178 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
 
 	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
 	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGlobalCommand() *schema.Resource {
+	resourceSchema := commandSchema()
+
+	resourceSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	resourceSchema["application_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
 	return &schema.Resource{
 		CreateContext: resourceCommandCreate,
 		ReadContext:   resourceCommandRead,
 		UpdateContext: resourceCommandUpdate,
 		DeleteContext: resourceCommandDelete,
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"application_id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"name": {
-				Type:         schema.TypeString,
-				Description:  "1-32 lowercase character name. Changing this will force recreation.",
-				Required:     true,
-				ValidateFunc: transforms.ValidateName,
-				ForceNew:     true,
-			},
-			"description": {
-				Type:         schema.TypeString,
-				Description:  "1-100 character description",
-				Required:     true,
-				ValidateFunc: transforms.ValidateDescription,
-			},
-			"default_permission": {
-				Type:        schema.TypeBool,
-				Description: "whether the command is enabled by default when the app is added to a guild",
-				Optional:    true,
-				Default:     true,
-			},
-			"option": optionsSchema(true),
+		CustomizeDiff: resourceCommandCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
+		Schema: resourceSchema,
+	}
+}
+
+// commandSchema is the configurable part of a command, shared between the command resources and command_set's command blocks.
+func commandSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Description:  "One of `chat_input` for slash commands, or `user` or `message` for context menu commands",
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "chat_input",
+			ValidateFunc: validation.StringInSlice(commandTypeNames(), false),
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Description:  "1-32 character name. Must be lowercase for `chat_input` commands, context menu commands may use spaces and capitals.",
+			Required:     true,
+			ValidateFunc: transforms.ValidateCommandName,
+		},
+		"description": {
+			Type:         schema.TypeString,
+			Description:  "1-100 character description. Required for `chat_input` commands, and not allowed for context menu commands.",
+			Optional:     true,
+			ValidateFunc: transforms.ValidateDescription,
+		},
+		"name_localizations":        localizationsSchema("name", transforms.ValidateCommandName),
+		"description_localizations": localizationsSchema("description", transforms.ValidateDescription),
+		"default_permission": {
+			Type:        schema.TypeBool,
+			Description: "whether the command is enabled by default when the app is added to a guild",
+			Optional:    true,
+			Default:     true,
+		},
+		"option": optionsSchema(1),
+	}
+}
+
+func commandTypeNames() []string {
+	typeNames := make([]string, 0, len(transforms.CommandTypes))
+	for typeName := range transforms.CommandTypes {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	return typeNames
+}
+
+func channelTypeNames() []string {
+	typeNames := make([]string, 0, len(transforms.ChannelTypes))
+	for typeName := range transforms.ChannelTypes {
+		typeNames = append(typeNames, typeName)
 	}
+	sort.Strings(typeNames)
+
+	return typeNames
 }
 
-func optionsSchema(allowNesting bool) *schema.Schema {
+// suppressEqualNumbers hides diffs between numbers that are only written differently, like `5.0` in configuration and `5` from Discord.
+func suppressEqualNumbers(key, old, new string, d *schema.ResourceData) bool {
+	return transforms.NumbersEqual(old, new)
+}
+
+// commandItem collects the commandSchema attributes of a resource, so it can be expanded like a command_set command block.
+func commandItem(resource *schema.ResourceData) map[string]interface{} {
+	item := map[string]interface{}{}
+
+	for key := range commandSchema() {
+		item[key] = resource.Get(key)
+	}
+
+	return item
+}
+
+// plannedCommandItem reads a command shaped like commandSchema from diff, under prefix (like `command.0.`) if it's nested.
+// Attributes whose values aren't known yet are left empty and listed in unknown.
+func plannedCommandItem(diff *schema.ResourceDiff, prefix string) (item map[string]interface{}, unknown map[string]bool) {
+	item, unknown = map[string]interface{}{}, map[string]bool{}
+
+	for key, attribute := range commandSchema() {
+		if !diff.NewValueKnown(prefix + key) {
+			item[key] = attribute.ZeroValue()
+			unknown[key] = true
+			continue
+		}
+
+		item[key] = diff.Get(prefix + key)
+	}
+
+	return
+}
+
+// maxOptionDepth is how deep options nest: SUB_COMMAND_GROUP, then SUB_COMMAND, then its parameters.
+const maxOptionDepth = 3
+
+// optionTypesAtDepth lists the option types Discord accepts at each level of nesting, starting at 1 for the command's own options.
+var optionTypesAtDepth = map[int][]int{
+	1: {
+		client.OptionTypeSubCommand, client.OptionTypeSubCommandGroup, client.OptionTypeString, client.OptionTypeInteger, client.OptionTypeBoolean, client.OptionTypeUser,
+		client.OptionTypeChannel, client.OptionTypeRole, client.OptionTypeMentionable, client.OptionTypeNumber, client.OptionTypeAttachment,
+	},
+	2: {
+		client.OptionTypeSubCommand, client.OptionTypeString, client.OptionTypeInteger, client.OptionTypeBoolean, client.OptionTypeUser,
+		client.OptionTypeChannel, client.OptionTypeRole, client.OptionTypeMentionable, client.OptionTypeNumber, client.OptionTypeAttachment,
+	},
+	3: {
+		client.OptionTypeString, client.OptionTypeInteger, client.OptionTypeBoolean, client.OptionTypeUser,
+		client.OptionTypeChannel, client.OptionTypeRole, client.OptionTypeMentionable, client.OptionTypeNumber, client.OptionTypeAttachment,
+	},
+}
+
+var optionDescriptionsAtDepth = map[int]string{
+	1: "Parameters for the command. SUB_COMMAND (1) and SUB_COMMAND_GROUP (2) options hold their own `option` blocks.",
+	2: "Options of a subcommand or subcommand group. A SUB_COMMAND_GROUP (2) may only hold SUB_COMMAND (1) options, which hold their own `option` blocks.",
+	3: "Parameters of a subcommand inside a subcommand group. These can't be subcommands.",
+}
+
+// optionsSchema builds the `option` block for a level of nesting, starting at 1. Each level only accepts the types Discord allows there,
+// and the last level has no `option` block of its own.
+func optionsSchema(depth int) *schema.Schema {
 	options := &schema.Schema{
 		Type:        schema.TypeList,
-		Description: "Parameters for the command. Note: As an implementation detail, making a subcommand group is supported, but only exactly one nesting down of subcommands.",
+		Description: optionDescriptionsAtDepth[depth],
 		Optional:    true,
 		MaxItems:    25,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"type": {
-					Type:        schema.TypeInt,
-					Optional:    true,
-					Description: "Type of the option, refer to documentation: https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type",
-					Default:     3,
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Description:  "Type of the option, refer to documentation: https://discord.com/developers/docs/interactions/slash-commands#application-command-object-application-command-option-type",
+					Default:      3,
+					ValidateFunc: validation.IntInSlice(optionTypesAtDepth[depth]),
 				},
 				"name": {
 					Type:         schema.TypeString,
@@ -73,15 +185,56 @@ func optionsSchema(allowNesting bool) *schema.Schema {
 					Required:     true,
 					ValidateFunc: transforms.ValidateDescription,
 				},
+				"name_localizations":        localizationsSchema("name", transforms.ValidateName),
+				"description_localizations": localizationsSchema("description", transforms.ValidateDescription),
 				"required": {
-					Type:     schema.TypeBool,
-					Optional: true,
-					Default:  false,
+					Type:        schema.TypeBool,
+					Description: "Whether the option must be given. Required options must come before optional ones.",
+					Optional:    true,
+					Default:     false,
+				},
+				"channel_types": {
+					Type:        schema.TypeSet,
+					Description: "Only offer these kinds of channels, for CHANNEL (7) options. Any of `text`, `dm`, `voice`, `group_dm`, `category`, `announcement`, `announcement_thread`, `public_thread`, `private_thread`, `stage`, `directory`, `forum` or `media`. Offers every kind if not set.",
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(channelTypeNames(), false),
+					},
+				},
+				"min_value": {
+					Type:             schema.TypeString,
+					Description:      "Smallest value allowed, for INTEGER (4) and NUMBER (10) options. A whole number for INTEGER options. A string, so `0` can be told apart from unset.",
+					Optional:         true,
+					ValidateFunc:     transforms.ValidateNumber,
+					DiffSuppressFunc: suppressEqualNumbers,
+				},
+				"max_value": {
+					Type:             schema.TypeString,
+					Description:      "Largest value allowed, for INTEGER (4) and NUMBER (10) options. A whole number for INTEGER options. A string, so `0` can be told apart from unset.",
+					Optional:         true,
+					ValidateFunc:     transforms.ValidateNumber,
+					DiffSuppressFunc: suppressEqualNumbers,
+				},
+				"min_length": {
+					Type:             schema.TypeString,
+					Description:      "Shortest value allowed, from 0 to 6000, for STRING (3) options. A string, so `0` can be told apart from unset.",
+					Optional:         true,
+					ValidateFunc:     transforms.ValidateLength(0),
+					DiffSuppressFunc: suppressEqualNumbers,
+				},
+				"max_length": {
+					Type:             schema.TypeString,
+					Description:      "Longest value allowed, from 1 to 6000, for STRING (3) options.",
+					Optional:         true,
+					ValidateFunc:     transforms.ValidateLength(1),
+					DiffSuppressFunc: suppressEqualNumbers,
 				},
 				"choice": {
-					Type:     schema.TypeList,
-					MaxItems: 25,
-					Optional: true,
+					Type:        schema.TypeList,
+					Description: "Predefined values to pick from. Only STRING (3), INTEGER (4) and NUMBER (10) options can have choices.",
+					MaxItems:    25,
+					Optional:    true,
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
 							"name": {
@@ -90,18 +243,22 @@ func optionsSchema(allowNesting bool) *schema.Schema {
 								ValidateFunc: transforms.ValidateDescription,
 								Required:     true,
 							},
+							"name_localizations": localizationsSchema("name", transforms.ValidateDescription),
 							"string_value": {
 								Type:         schema.TypeString,
+								Description:  "Value for STRING (3) options. Exactly one value must be set, matching the option type.",
 								ValidateFunc: transforms.ValidateDescription,
 								Optional:     true,
 							},
 							"int_value": {
-								Type:     schema.TypeInt,
-								Optional: true,
+								Type:        schema.TypeInt,
+								Description: "Value for INTEGER (4) options. Exactly one value must be set, matching the option type.",
+								Optional:    true,
 							},
 							"float_value": {
-								Type:     schema.TypeFloat,
-								Optional: true,
+								Type:        schema.TypeFloat,
+								Description: "Value for NUMBER (10) options. Exactly one value must be set, matching the option type.",
+								Optional:    true,
 							},
 						},
 					},
@@ -110,45 +267,74 @@ func optionsSchema(allowNesting bool) *schema.Schema {
 		},
 	}
 
-	if allowNesting {
-		options.Elem.(*schema.Resource).Schema["option"] = optionsSchema(false)
+	if depth < maxOptionDepth {
+		options.Elem.(*schema.Resource).Schema["option"] = optionsSchema(depth + 1)
 	}
 
 	return options
 }
 
+// localizationsSchema is a map of locale to a localized version of attribute, each validated like the attribute itself.
+func localizationsSchema(attribute string, validateValue schema.SchemaValidateFunc) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Description:  fmt.Sprintf("Localized versions of `%s`, keyed by locale like `pt-BR` or `ja`. Refer to documentation for supported locales: https://discord.com/developers/docs/reference#locales", attribute),
+		Optional:     true,
+		ValidateFunc: transforms.ValidateLocalizations(validateValue),
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
 // resourceGuildCommand adds guild_id to resourceGlobalCommand as that's the only difference
 func resourceGuildCommand() *schema.Resource {
 	resource := resourceGlobalCommand()
 
+	// Commands can't move between guilds, and updates PATCH the command by ID within its guild.
 	resource.Schema["guild_id"] = &schema.Schema{
 		Type:         schema.TypeString,
 		Required:     true,
+		ForceNew:     true,
 		ValidateFunc: transforms.ValidateSnowflake,
 	}
 
 	return resource
 }
 
+// resourceCommandCustomizeDiff validates the command as a whole at plan time, see transforms.ValidateCommand.
+// Attributes that aren't known yet, like a description from another resource, only skip the checks that need them.
+func resourceCommandCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	commandItem, unknown := plannedCommandItem(diff, "")
+
+	// The size warning can't be shown at plan time: CustomizeDiff can only return an error, and the SDK doesn't run
+	// ValidateDiagFunc on lists like `option`, while a single attribute's validation can't see the whole command.
+	// So it's only logged here, and added to the apply's diagnostics by commandSizeDiagnostics.
+	if len(unknown) == 0 {
+		if warning := transforms.CommandSizeWarning(transforms.ExpandCommand(commandItem)); warning != "" {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+
+	return validationError(transforms.ValidateKnownCommand(commandItem, unknown, ""))
+}
+
 func resourceCommandCreate(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*client.InteractionsClient)
 	guildID, _ := resource.Get("guild_id").(string)
 
-	command := &client.InteractionCommand{
-		Name:              resource.Get("name").(string),
-		Description:       resource.Get("description").(string),
-		DefaultPermission: resource.Get("default_permission").(bool),
-		Options:           transforms.ExpandOptions(resource.Get("option").([]interface{})),
-	}
+	command := transforms.ExpandCommand(commandItem(resource))
+
+	diags := commandSizeDiagnostics(command, cty.Path{})
 
-	command, err := c.UpsertInteractionCommand(guildID, command)
+	command, err := c.UpsertInteractionCommand(ctx, guildID, command)
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, commandErrorDiagnostics(err, cty.Path{}, resource.Get("option").([]interface{}))...)
 	}
 
 	resource.SetId(command.ID)
 
-	return resourceCommandRead(ctx, resource, m)
+	return append(diags, resourceCommandRead(ctx, resource, m)...)
 }
 
 func resourceCommandRead(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -157,7 +343,13 @@ func resourceCommandRead(ctx context.Context, resource *schema.ResourceData, m i
 	c := m.(*client.InteractionsClient)
 	guildID, _ := resource.Get("guild_id").(string)
 
-	command, err := c.GetInteractionCommand(guildID, resource.Id())
+	command, err := c.GetInteractionCommand(ctx, guildID, resource.Id())
+	if client.IsNotFound(err) {
+		log.Printf("[WARN] command %s (%s) no longer exists, removing from state", resource.Id(), resource.Get("name"))
+		resource.SetId("")
+		return diags
+	}
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -177,22 +369,37 @@ func resourceCommandUpdate(ctx context.Context, resource *schema.ResourceData, m
 	c := m.(*client.InteractionsClient)
 	guildID, _ := resource.Get("guild_id").(string)
 
-	command := &client.InteractionCommand{
-		ID:                resource.Id(),
-		Name:              resource.Get("name").(string),
-		Description:       resource.Get("description").(string),
-		DefaultPermission: resource.Get("default_permission").(bool),
-		Options:           transforms.ExpandOptions(resource.Get("option").([]interface{})),
+	command := transforms.ExpandCommand(commandItem(resource))
+
+	// POST would upsert by name, so a rename would create a new command. PATCH keeps the ID (and its permissions.)
+	patch := &client.InteractionCommandPatch{}
+	if resource.HasChange("name") {
+		patch.Name = &command.Name
+	}
+	if resource.HasChange("description") {
+		patch.Description = &command.Description
+	}
+	if resource.HasChange("name_localizations") {
+		patch.NameLocalizations = localizationsPatch(command.NameLocalizations)
+	}
+	if resource.HasChange("description_localizations") {
+		patch.DescriptionLocalizations = localizationsPatch(command.DescriptionLocalizations)
+	}
+	if resource.HasChange("default_permission") {
+		patch.DefaultPermission = command.DefaultPermission
 	}
+	if resource.HasChange("option") {
+		patch.Options = &command.Options
+	}
+
+	diags := commandSizeDiagnostics(command, cty.Path{})
 
-	command, err := c.UpsertInteractionCommand(guildID, command)
+	_, err := c.PatchInteractionCommand(ctx, guildID, resource.Id(), patch)
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, commandErrorDiagnostics(err, cty.Path{}, resource.Get("option").([]interface{}))...)
 	}
 
-	resource.SetId(command.ID)
-
-	return resourceCommandRead(ctx, resource, m)
+	return append(diags, resourceCommandRead(ctx, resource, m)...)
 }
 
 func resourceCommandDelete(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -201,10 +408,20 @@ func resourceCommandDelete(ctx context.Context, resource *schema.ResourceData, m
 	c := m.(*client.InteractionsClient)
 	guildID, _ := resource.Get("guild_id").(string)
 
-	err := c.DeleteInteractionCommand(guildID, resource.Id())
-	if err != nil {
+	// Someone else already deleted it, which is what we wanted anyway.
+	err := c.DeleteInteractionCommand(ctx, guildID, resource.Id())
+	if err != nil && !client.IsNotFound(err) {
 		return diag.FromErr(err)
 	}
 
 	return diags
 }
+
+// localizationsPatch sends an empty map rather than nothing when every localization is removed, so Discord clears them.
+func localizationsPatch(localizations map[string]string) *map[string]string {
+	if localizations == nil {
+		localizations = map[string]string{}
+	}
+
+	return &localizations
+}
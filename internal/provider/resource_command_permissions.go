@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCommandPermissions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the permission overwrites of a command in a guild. Overwrites not declared here are removed. " +
+			"These take precedence over application-wide overwrites from `discord-interactions_application_guild_permissions` for the same role, user or channel.",
+		CreateContext: resourceCommandPermissionsCreate,
+		ReadContext:   resourceCommandPermissionsRead,
+		UpdateContext: resourceCommandPermissionsUpdate,
+		DeleteContext: resourceCommandPermissionsDelete,
+		CustomizeDiff: resourceCommandPermissionsCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"guild_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: transforms.ValidateSnowflake,
+			},
+			"command_id": {
+				Type:         schema.TypeString,
+				Description:  "ID of the command, global or for this guild",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: transforms.ValidateSnowflake,
+			},
+			"permission": permissionsSchema(),
+		},
+	}
+}
+
+func permissionsSchema() *schema.Schema {
+	typeNames := make([]string, 0, len(transforms.PermissionTypes))
+	for typeName := range transforms.PermissionTypes {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: "Permission overwrites",
+		Optional:    true,
+		MaxItems:    100,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Description:  "One of `role`, `user` or `channel`",
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(typeNames, false),
+				},
+				"id": {
+					Type:         schema.TypeString,
+					Description:  "ID of the role, user or channel. Use `everyone` with type `role` for @everyone, or `all_channels` with type `channel` for every channel in the guild.",
+					Required:     true,
+					ValidateFunc: transforms.ValidatePermissionTarget,
+				},
+				"allow": {
+					Type:        schema.TypeBool,
+					Description: "true to allow using the command, false to deny it",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+// resourceCommandPermissionsCustomizeDiff keeps application-wide overwrites, which use the application ID as a command ID,
+// owned by discord-interactions_application_guild_permissions so the two resources never fight over them.
+func resourceCommandPermissionsCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	c := m.(*client.InteractionsClient)
+
+	if diff.NewValueKnown("command_id") {
+		err := validatePermissionsCommandID(diff.Get("command_id").(string), c.ApplicationID())
+		if err != nil {
+			return err
+		}
+	}
+
+	return permissionsCustomizeDiff(ctx, diff, m)
+}
+
+// permissionsCustomizeDiff checks the `permission` blocks at plan time, see transforms.ValidatePermissions.
+func permissionsCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("permission") {
+		return nil
+	}
+
+	return validationError(transforms.ValidatePermissions(diff.Get("permission").(*schema.Set).List(), "permission"))
+}
+
+// validatePermissionsCommandID rejects the application ID, which Discord treats as every command of the application.
+func validatePermissionsCommandID(commandID, applicationID string) error {
+	if commandID == applicationID {
+		return fmt.Errorf("command_id is the application ID, use discord-interactions_application_guild_permissions to manage application-wide permissions instead")
+	}
+
+	return nil
+}
+
+func resourceCommandPermissionsCreate(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	guildID := resource.Get("guild_id").(string)
+	commandID := resource.Get("command_id").(string)
+
+	diags := editPermissions(ctx, resource, m, guildID, commandID)
+	if diags.HasError() {
+		return diags
+	}
+
+	resource.SetId(guildID + "/" + commandID)
+
+	return resourceCommandPermissionsRead(ctx, resource, m)
+}
+
+func resourceCommandPermissionsRead(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	guildID, commandID, err := parseCommandPermissionsID(resource.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readPermissions(ctx, resource, m, guildID, commandID)
+}
+
+func resourceCommandPermissionsUpdate(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	guildID, commandID, err := parseCommandPermissionsID(resource.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := editPermissions(ctx, resource, m, guildID, commandID)
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceCommandPermissionsRead(ctx, resource, m)
+}
+
+func resourceCommandPermissionsDelete(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	guildID, commandID, err := parseCommandPermissionsID(resource.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return clearPermissions(ctx, m, guildID, commandID)
+}
+
+func parseCommandPermissionsID(id string) (guildID string, commandID string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected ID format, wanted: guild_id/command_id, got: %s", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// editPermissions replaces the overwrites for commandID with the configured `permission` blocks.
+func editPermissions(ctx context.Context, resource *schema.ResourceData, m interface{}, guildID, commandID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.InteractionsClient)
+
+	permissions, err := transforms.ExpandPermissions(resource.Get("permission").(*schema.Set).List(), guildID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = c.EditCommandPermissions(ctx, guildID, commandID, permissions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// readPermissions refreshes `permission` from Discord, removing the resource from state if the command is gone.
+func readPermissions(ctx context.Context, resource *schema.ResourceData, m interface{}, guildID, commandID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.InteractionsClient)
+
+	permissions, err := c.GetCommandPermissions(ctx, guildID, commandID)
+	if client.IsNotFound(err) {
+		log.Printf("[WARN] command %s no longer exists in guild %s, removing permissions from state", commandID, guildID)
+		resource.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = resource.Set("guild_id", guildID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = resource.Set("permission", transforms.FlattenPermissions(permissions.Permissions, guildID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// clearPermissions removes every overwrite for commandID. A command that's already gone has nothing left to clear.
+func clearPermissions(ctx context.Context, m interface{}, guildID, commandID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.InteractionsClient)
+
+	_, err := c.EditCommandPermissions(ctx, guildID, commandID, nil)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
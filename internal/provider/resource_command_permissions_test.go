@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+const (
+	fakeApplicationID = "386659935687147521"
+	fakeGuildID       = "386659935687147520"
+)
+
+func TestValidatePermissionsCommandID(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		commandID string
+		valid     bool
+	}{
+		{
+			desc:      "command",
+			commandID: "386659935687147522",
+			valid:     true,
+		},
+		{
+			desc:      "application",
+			commandID: fakeApplicationID,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := validatePermissionsCommandID(tC.commandID, fakeApplicationID)
+			if (err == nil) != tC.valid {
+				t.Errorf("expected valid: %v, got: %v", tC.valid, err)
+			}
+		})
+	}
+}
+
+func TestApplicationGuildPermissions(t *testing.T) {
+	permissionsPath := "/applications/" + fakeApplicationID + "/guilds/" + fakeGuildID + "/commands/" + fakeApplicationID + "/permissions"
+
+	var requests []string
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == "PUT" && r.Header.Get("Authorization") != "Bearer bearer" {
+			t.Errorf("expected permissions to be edited with the bearer token, got: %s", r.Header.Get("Authorization"))
+		}
+
+		if r.URL.Path != permissionsPath {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method == "PUT" {
+			sent = nil
+			err := json.NewDecoder(r.Body).Decode(&sent)
+			if err != nil {
+				t.Errorf("failed to decode body, %v", err)
+			}
+		}
+
+		rw.Write([]byte(`{
+			"id": "` + fakeApplicationID + `",
+			"application_id": "` + fakeApplicationID + `",
+			"guild_id": "` + fakeGuildID + `",
+			"permissions": [{"id": "` + fakeGuildID + `", "type": 1, "permission": false}]
+		}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewInteractionsClient(client.ClientConfig{
+		ApplicationID:     fakeApplicationID,
+		BotToken:          "token",
+		ClientCredentials: "bearer",
+		APIRoot:           server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client, %v", err)
+	}
+
+	resource := schema.TestResourceDataRaw(t, resourceApplicationGuildPermissions().Schema, map[string]interface{}{
+		"guild_id": fakeGuildID,
+		"permission": []interface{}{
+			map[string]interface{}{"type": "role", "id": "everyone", "allow": false},
+		},
+	})
+
+	diags := resourceApplicationGuildPermissionsCreate(context.Background(), resource, c)
+	if diags.HasError() {
+		t.Fatalf("failed to create, %v", diags)
+	}
+
+	if resource.Id() != fakeGuildID {
+		t.Errorf("expected ID to be the guild ID, got: %s", resource.Id())
+	}
+
+	if sentPermissions, ok := sent["permissions"].([]interface{}); !ok || len(sentPermissions) != 1 {
+		t.Errorf("expected one overwrite to be sent, got: %v", sent)
+	}
+
+	permissions := resource.Get("permission").(*schema.Set).List()
+	if len(permissions) != 1 || permissions[0].(map[string]interface{})["id"] != "everyone" {
+		t.Errorf("expected @everyone overwrite to be read, got: %v", permissions)
+	}
+
+	diags = resourceApplicationGuildPermissionsDelete(context.Background(), resource, c)
+	if diags.HasError() {
+		t.Fatalf("failed to delete, %v", diags)
+	}
+
+	if sentPermissions, ok := sent["permissions"].([]interface{}); !ok || len(sentPermissions) != 0 {
+		t.Errorf("expected overwrites to be cleared with an empty list, got: %v", sent)
+	}
+
+	expected := []string{"PUT " + permissionsPath, "GET " + permissionsPath, "PUT " + permissionsPath}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected requests %v, got: %v", expected, requests)
+	}
+
+	for i := range requests {
+		if requests[i] != expected[i] {
+			t.Errorf("expected requests %v, got: %v", expected, requests)
+		}
+	}
+}
+
+func TestPermissionsPlan(t *testing.T) {
+	c, err := client.NewInteractionsClient(client.ClientConfig{
+		ApplicationID: fakeApplicationID,
+		BotToken:      "token",
+		APIRoot:       "http://localhost",
+	})
+	if err != nil {
+		t.Fatalf("failed to create client, %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		resource *schema.Resource
+		config   map[string]interface{}
+		valid    bool
+	}{
+		{
+			desc:     "command, everyone role",
+			resource: resourceCommandPermissions(),
+			config: map[string]interface{}{
+				"guild_id":   fakeGuildID,
+				"command_id": "1234",
+				"permission": []interface{}{map[string]interface{}{"type": "role", "id": "everyone", "allow": true}},
+			},
+			valid: true,
+		},
+		{
+			desc:     "command, everyone user",
+			resource: resourceCommandPermissions(),
+			config: map[string]interface{}{
+				"guild_id":   fakeGuildID,
+				"command_id": "1234",
+				"permission": []interface{}{map[string]interface{}{"type": "user", "id": "everyone", "allow": true}},
+			},
+		},
+		{
+			desc:     "application, all_channels channel",
+			resource: resourceApplicationGuildPermissions(),
+			config: map[string]interface{}{
+				"guild_id":   fakeGuildID,
+				"permission": []interface{}{map[string]interface{}{"type": "channel", "id": "all_channels", "allow": false}},
+			},
+			valid: true,
+		},
+		{
+			desc:     "application, all_channels role",
+			resource: resourceApplicationGuildPermissions(),
+			config: map[string]interface{}{
+				"guild_id":   fakeGuildID,
+				"permission": []interface{}{map[string]interface{}{"type": "role", "id": "all_channels", "allow": false}},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := tC.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tC.config), c)
+			if (err == nil) != tC.valid {
+				t.Errorf("expected valid: %v, got: %v", tC.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// globalCommandSetID is the ID of a command_set without a guild_id.
+const globalCommandSetID = "global"
+
+func resourceCommandSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Authoritatively manages every command in a scope, either global or a single guild. " +
+			"Commands not declared here are deleted, so don't mix this with `discord-interactions_global_command` or `discord-interactions_guild_command` in the same scope.",
+		CreateContext: resourceCommandSetCreate,
+		ReadContext:   resourceCommandSetRead,
+		UpdateContext: resourceCommandSetUpdate,
+		DeleteContext: resourceCommandSetDelete,
+		CustomizeDiff: resourceCommandSetCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCommandSetImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"guild_id": {
+				Type:         schema.TypeString,
+				Description:  "Guild to manage commands for. If not set, global commands are managed instead.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: transforms.ValidateSnowflake,
+			},
+			"command": {
+				Type:        schema.TypeList,
+				Description: "Commands in this scope. Same as the arguments of `discord-interactions_global_command`.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: commandSchema(),
+				},
+			},
+			"command_ids": {
+				Type:        schema.TypeMap,
+				Description: "Map of command name to command ID. Context menu commands are keyed by type and name, like `message:Report Message`, as they may share a name with other commands.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceCommandSetCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	// Any command may be created or recreated by the overwrite, so IDs are only known after apply.
+	if diff.HasChange("command") {
+		err := diff.SetNewComputed("command_ids")
+		if err != nil {
+			return err
+		}
+	}
+
+	if !diff.NewValueKnown("command.#") {
+		return nil
+	}
+
+	var errs []error
+	keys := map[string]bool{}
+	for i := range diff.Get("command").([]interface{}) {
+		path := fmt.Sprintf("command.%d", i)
+		item, unknown := plannedCommandItem(diff, path+".")
+		errs = append(errs, transforms.ValidateKnownCommand(item, unknown, path)...)
+
+		// Names are unique per type, Discord rejects the whole overwrite otherwise.
+		if !unknown["type"] && !unknown["name"] {
+			typeName, _ := item["type"].(string)
+			name, _ := item["name"].(string)
+
+			key := commandSetKey(transforms.CommandTypes[typeName], name)
+			if keys[key] {
+				errs = append(errs, fmt.Errorf("%s: %s command `%s` is declared more than once", path, typeName, name))
+			}
+			keys[key] = true
+		}
+
+		// Only logged, as there's no way to return a warning at plan time, see resourceCommandCustomizeDiff.
+		if len(unknown) != 0 {
+			continue
+		}
+
+		if warning := transforms.CommandSizeWarning(transforms.ExpandCommand(item)); warning != "" {
+			log.Printf("[WARN] %s: %s", path, warning)
+		}
+	}
+
+	return validationError(errs)
+}
+
+// resourceCommandSetImport takes a guild ID, or `global` for global commands.
+func resourceCommandSetImport(ctx context.Context, resource *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := resource.Id()
+	if id == globalCommandSetID {
+		return []*schema.ResourceData{resource}, nil
+	}
+
+	_, errs := transforms.ValidateSnowflake(id, "id")
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("import ID must be a guild ID or %q, got: %s", globalCommandSetID, id)
+	}
+
+	err := resource.Set("guild_id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{resource}, nil
+}
+
+func resourceCommandSetCreate(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceCommandSetOverwrite(ctx, resource, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	guildID, _ := resource.Get("guild_id").(string)
+	if guildID == "" {
+		resource.SetId(globalCommandSetID)
+	} else {
+		resource.SetId(guildID)
+	}
+
+	return append(diags, resourceCommandSetRead(ctx, resource, m)...)
+}
+
+func resourceCommandSetRead(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.InteractionsClient)
+	guildID, _ := resource.Get("guild_id").(string)
+
+	commands, err := c.GetInteractionCommands(ctx, guildID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	commands = orderCommands(commands, resource.Get("command").([]interface{}))
+
+	commandAttributes := commandSchema()
+	commandItems := make([]interface{}, len(commands))
+	commandIDs := make(map[string]interface{}, len(commands))
+
+	for i, command := range commands {
+		commandItem := transforms.FlattenCommand(command)
+		for key := range commandItem {
+			if _, ok := commandAttributes[key]; !ok {
+				delete(commandItem, key)
+			}
+		}
+
+		commandItems[i] = commandItem
+		commandIDs[commandSetKey(command.Type, command.Name)] = command.ID
+	}
+
+	err = resource.Set("command", commandItems)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = resource.Set("command_ids", commandIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceCommandSetUpdate(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceCommandSetOverwrite(ctx, resource, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceCommandSetRead(ctx, resource, m)...)
+}
+
+func resourceCommandSetDelete(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.InteractionsClient)
+	guildID, _ := resource.Get("guild_id").(string)
+
+	_, err := c.BulkOverwriteInteractionCommands(ctx, guildID, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceCommandSetOverwrite replaces every command in the scope with the configured ones.
+func resourceCommandSetOverwrite(ctx context.Context, resource *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.InteractionsClient)
+	guildID, _ := resource.Get("guild_id").(string)
+
+	commandItems := resource.Get("command").([]interface{})
+	commands := make([]*client.InteractionCommand, len(commandItems))
+	for i, commandItem := range commandItems {
+		commands[i] = transforms.ExpandCommand(commandItem.(map[string]interface{}))
+		diags = append(diags, commandSizeDiagnostics(commands[i], cty.GetAttrPath("command").IndexInt(i))...)
+	}
+
+	_, err := c.BulkOverwriteInteractionCommands(ctx, guildID, commands)
+	if err != nil {
+		return append(diags, commandSetErrorDiagnostics(err, commandItems)...)
+	}
+
+	return diags
+}
+
+// orderCommands sorts commands from Discord into the order they're configured in, so reads don't cause diffs.
+// Commands that aren't configured (i.e. drift) are put at the end, by name.
+// Commands are matched by type and name, as a context menu command may share its name with another command.
+func orderCommands(commands []*client.InteractionCommand, commandItems []interface{}) []*client.InteractionCommand {
+	positions := make(map[string]int, len(commandItems))
+	for i, commandItem := range commandItems {
+		item, _ := commandItem.(map[string]interface{})
+		typeName, _ := item["type"].(string)
+		name, _ := item["name"].(string)
+		positions[commandSetKey(transforms.CommandTypes[typeName], name)] = i
+	}
+
+	ordered := make([]*client.InteractionCommand, len(commands))
+	copy(ordered, commands)
+
+	sort.SliceStable(ordered, func(a, b int) bool {
+		positionA, configuredA := positions[commandSetKey(ordered[a].Type, ordered[a].Name)]
+		positionB, configuredB := positions[commandSetKey(ordered[b].Type, ordered[b].Name)]
+
+		if configuredA && configuredB {
+			return positionA < positionB
+		}
+
+		if configuredA != configuredB {
+			return configuredA
+		}
+
+		return commandSetKey(ordered[a].Type, ordered[a].Name) < commandSetKey(ordered[b].Type, ordered[b].Name)
+	})
+
+	return ordered
+}
+
+// commandSetKey identifies a command within a scope, where names are only unique per command type.
+// Chat input commands are keyed by their name alone, context menu commands by their type and name, like `user:Ban`.
+func commandSetKey(commandType int, name string) string {
+	if commandType == 0 || commandType == client.CommandTypeChatInput {
+		return name
+	}
+
+	return transforms.FlattenCommandType(commandType) + ":" + name
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+func TestOrderCommands(t *testing.T) {
+	commands := []*client.InteractionCommand{
+		{ID: "1", Type: client.CommandTypeChatInput, Name: "zebra"},
+		{ID: "2", Type: client.CommandTypeUser, Name: "ban"},
+		{ID: "3", Type: client.CommandTypeChatInput, Name: "ban"},
+		{ID: "4", Type: client.CommandTypeChatInput, Name: "alpaca"},
+		{ID: "5", Type: client.CommandTypeMessage, Name: "Report"},
+	}
+
+	testCases := []struct {
+		desc         string
+		commandItems []interface{}
+		expected     []string
+	}{
+		{
+			desc: "configured order is kept",
+			commandItems: []interface{}{
+				map[string]interface{}{"type": "chat_input", "name": "zebra"},
+				map[string]interface{}{"type": "message", "name": "Report"},
+				map[string]interface{}{"type": "chat_input", "name": "alpaca"},
+				map[string]interface{}{"type": "user", "name": "ban"},
+				map[string]interface{}{"type": "chat_input", "name": "ban"},
+			},
+			expected: []string{"1", "5", "4", "2", "3"},
+		},
+		{
+			desc: "drift is sorted last by key",
+			commandItems: []interface{}{
+				map[string]interface{}{"type": "chat_input", "name": "zebra"},
+			},
+			// alpaca, ban, message:Report, user:ban
+			expected: []string{"1", "4", "3", "5", "2"},
+		},
+		{
+			desc: "same name, different types",
+			commandItems: []interface{}{
+				map[string]interface{}{"type": "user", "name": "ban"},
+				map[string]interface{}{"type": "chat_input", "name": "ban"},
+			},
+			expected: []string{"2", "3", "4", "5", "1"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ordered := orderCommands(commands, tC.commandItems)
+
+			ids := make([]string, len(ordered))
+			for i, command := range ordered {
+				ids[i] = command.ID
+			}
+
+			if len(ids) != len(tC.expected) {
+				t.Fatalf("expected %v, got: %v", tC.expected, ids)
+			}
+
+			for i := range ids {
+				if ids[i] != tC.expected[i] {
+					t.Fatalf("expected %v, got: %v", tC.expected, ids)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandSetKey(t *testing.T) {
+	testCases := []struct {
+		commandType int
+		name        string
+		expected    string
+	}{
+		{commandType: 0, name: "ban", expected: "ban"},
+		{commandType: client.CommandTypeChatInput, name: "ban", expected: "ban"},
+		{commandType: client.CommandTypeUser, name: "Ban", expected: "user:Ban"},
+		{commandType: client.CommandTypeMessage, name: "Report Message", expected: "message:Report Message"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.expected, func(t *testing.T) {
+			if key := commandSetKey(tC.commandType, tC.name); key != tC.expected {
+				t.Errorf("key did not match, got: %s, wanted: %s", key, tC.expected)
+			}
+		})
+	}
+}
+
+func TestCommandSetImport(t *testing.T) {
+	testCases := []struct {
+		id      string
+		guildID string
+		valid   bool
+	}{
+		{id: "global", valid: true},
+		{id: "386659935687147520", guildID: "386659935687147520", valid: true},
+		{id: "not-a-guild"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.id, func(t *testing.T) {
+			resource := schema.TestResourceDataRaw(t, resourceCommandSet().Schema, map[string]interface{}{})
+			resource.SetId(tC.id)
+
+			_, err := resourceCommandSetImport(context.Background(), resource, nil)
+			if (err == nil) != tC.valid {
+				t.Fatalf("expected valid: %v, got: %v", tC.valid, err)
+			}
+
+			if guildID := resource.Get("guild_id").(string); tC.valid && guildID != tC.guildID {
+				t.Errorf("guild_id did not match, got: %s, wanted: %s", guildID, tC.guildID)
+			}
+		})
+	}
+}
+
+func TestCommandSetReadIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte(`[
+			{"id": "1", "type": 1, "name": "ban", "description": "Ban someone"},
+			{"id": "2", "type": 2, "name": "Ban"},
+			{"id": "3", "type": 3, "name": "Report Message"}
+		]`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewInteractionsClient(client.ClientConfig{
+		ApplicationID: "386659935687147521",
+		BotToken:      "token",
+		APIRoot:       server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client, %v", err)
+	}
+
+	resource := schema.TestResourceDataRaw(t, resourceCommandSet().Schema, map[string]interface{}{
+		"guild_id": "386659935687147520",
+	})
+	resource.SetId("386659935687147520")
+
+	diags := resourceCommandSetRead(context.Background(), resource, c)
+	if diags.HasError() {
+		t.Fatalf("failed to read, %v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"ban":                    "1",
+		"user:Ban":               "2",
+		"message:Report Message": "3",
+	}
+	commandIDs := resource.Get("command_ids").(map[string]interface{})
+	if len(commandIDs) != len(expected) {
+		t.Errorf("expected %v, got: %v", expected, commandIDs)
+	}
+
+	for key, id := range expected {
+		if commandIDs[key] != id {
+			t.Errorf("expected %s to be %s, got: %v", key, id, commandIDs[key])
+		}
+	}
+}
+
+func TestCommandSetPlanDuplicates(t *testing.T) {
+	chatInput := func(name string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "description": "A command"}
+	}
+	contextMenu := func(typeName, name string) map[string]interface{} {
+		return map[string]interface{}{"type": typeName, "name": name}
+	}
+
+	testCases := []struct {
+		desc     string
+		commands []interface{}
+		errors   string
+	}{
+		{
+			desc:     "unique",
+			commands: []interface{}{chatInput("ban"), chatInput("kick")},
+		},
+		{
+			desc:     "same name, different types",
+			commands: []interface{}{chatInput("ban"), contextMenu("user", "ban"), contextMenu("message", "ban")},
+		},
+		{
+			desc:     "same chat input name",
+			commands: []interface{}{chatInput("ban"), chatInput("kick"), chatInput("ban")},
+			errors:   "command.2: chat_input command `ban` is declared more than once",
+		},
+		{
+			desc:     "same context menu name",
+			commands: []interface{}{contextMenu("user", "Ban"), contextMenu("user", "Ban")},
+			errors:   "command.1: user command `Ban` is declared more than once",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"guild_id": "386659935687147520",
+				"command":  tC.commands,
+			})
+
+			_, err := resourceCommandSet().Diff(context.Background(), nil, config, nil)
+			if tC.errors == "" && err != nil {
+				t.Errorf("expected no errors, got: %v", err)
+			}
+
+			if tC.errors != "" && (err == nil || !strings.Contains(err.Error(), tC.errors)) {
+				t.Errorf("expected %q, got: %v", tC.errors, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
 )
@@ -17,13 +19,13 @@ func init() {
 		F: func(guildID string) error {
 			c := getClient()
 
-			commands, err := c.GetInteractionCommands(guildID)
+			commands, err := c.GetInteractionCommands(context.Background(), guildID)
 			if err != nil {
 				return fmt.Errorf("error getting commands: %w", err)
 			}
 			for _, command := range commands {
 				if strings.HasPrefix(command.Name, "test-acc") {
-					err := c.DeleteInteractionCommand(guildID, command.ID)
+					err := c.DeleteInteractionCommand(context.Background(), guildID, command.ID)
 
 					if err != nil {
 						log.Printf("Error destroying %s during sweep: %v", command.Name, err)
@@ -72,7 +74,7 @@ func testAccGuildCommandCreated(path, guildID string, commandOut *client.Interac
 
 		c := getClient()
 
-		command, err := c.GetInteractionCommand(guildID, id)
+		command, err := c.GetInteractionCommand(context.Background(), guildID, id)
 		if err != nil {
 			return fmt.Errorf("failed to get command, %w", err)
 		}
@@ -105,7 +107,7 @@ func testAccGuildCommandDestroy(guildID, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		c := getClient()
 
-		commands, err := c.GetInteractionCommands(guildID)
+		commands, err := c.GetInteractionCommands(context.Background(), guildID)
 		if err != nil {
 			return fmt.Errorf("failed to get all commands, %w", err)
 		}
@@ -129,3 +131,211 @@ func testAccResourceGuildCommand(guildID, name, description string) string {
 	}
 	`, guildID, name, description)
 }
+
+func TestGuildCommandPlan(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":                 "1234",
+			"guild_id":           "386659935687147520",
+			"type":               "chat_input",
+			"name":               "hello-world",
+			"description":        "Say hello",
+			"default_permission": "true",
+		},
+	}
+
+	testCases := []struct {
+		desc        string
+		guildID     string
+		description string
+		replaces    bool
+	}{
+		{
+			desc:        "description changed",
+			guildID:     "386659935687147520",
+			description: "Say hi",
+		},
+		{
+			desc:        "guild_id changed",
+			guildID:     "386659935687147522",
+			description: "Say hello",
+			replaces:    true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"guild_id":    tC.guildID,
+				"name":        "hello-world",
+				"description": tC.description,
+			})
+
+			diff, err := resourceGuildCommand().Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatalf("failed to plan, %v", err)
+			}
+
+			if diff == nil {
+				t.Fatalf("expected a diff")
+			}
+
+			if diff.RequiresNew() != tC.replaces {
+				t.Errorf("expected replacement: %v, got diff: %v", tC.replaces, diff)
+			}
+		})
+	}
+}
+
+// unknownValue is how the SDK marks a value that isn't known until apply, like another resource's output.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestCommandPlanWithUnknowns(t *testing.T) {
+	options := func(first, second bool) []interface{} {
+		return []interface{}{
+			map[string]interface{}{"type": 3, "name": "first", "description": "First", "required": first},
+			map[string]interface{}{"type": 3, "name": "second", "description": "Second", "required": second},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		resource *schema.Resource
+		config   map[string]interface{}
+		errors   string
+	}{
+		{
+			desc:     "unknown description, valid options",
+			resource: resourceGuildCommand(),
+			config: map[string]interface{}{
+				"guild_id":    "386659935687147520",
+				"name":        "hello-world",
+				"description": unknownValue,
+				"option":      options(true, false),
+			},
+		},
+		{
+			desc:     "unknown description, invalid options",
+			resource: resourceGuildCommand(),
+			config: map[string]interface{}{
+				"guild_id":    "386659935687147520",
+				"name":        "hello-world",
+				"description": unknownValue,
+				"option":      options(false, true),
+			},
+			errors: "option.1",
+		},
+		{
+			desc:     "unknown localizations, invalid options",
+			resource: resourceGuildCommand(),
+			config: map[string]interface{}{
+				"guild_id":           "386659935687147520",
+				"name":               "hello-world",
+				"description":        "Say hello",
+				"name_localizations": unknownValue,
+				"option":             options(false, true),
+			},
+			errors: "option.1",
+		},
+		{
+			desc:     "command set, unknown description, invalid options",
+			resource: resourceCommandSet(),
+			config: map[string]interface{}{
+				"command": []interface{}{
+					map[string]interface{}{
+						"name":        "hello-world",
+						"description": unknownValue,
+						"option":      options(false, true),
+					},
+				},
+			},
+			errors: "command.0.option.1",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := tC.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tC.config), nil)
+			if tC.errors == "" && err != nil {
+				t.Errorf("expected no errors, got: %v", err)
+			}
+
+			if tC.errors != "" && (err == nil || !strings.Contains(err.Error(), tC.errors)) {
+				t.Errorf("expected an error at %s, got: %v", tC.errors, err)
+			}
+		})
+	}
+}
+
+func TestOptionLimitsPlan(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":                       "1234",
+			"guild_id":                 "386659935687147520",
+			"type":                     "chat_input",
+			"name":                     "hello-world",
+			"description":              "Say hello",
+			"default_permission":       "true",
+			"option.#":                 "1",
+			"option.0.type":            "3",
+			"option.0.name":            "message",
+			"option.0.description":     "What to say",
+			"option.0.required":        "false",
+			"option.0.min_length":      "5",
+			"option.0.max_length":      "100",
+			"option.0.choice.#":        "0",
+			"option.0.channel_types.#": "0",
+		},
+	}
+
+	testCases := []struct {
+		desc      string
+		minLength string
+		maxLength string
+		changes   bool
+	}{
+		{
+			desc:      "same",
+			minLength: "5",
+			maxLength: "100",
+		},
+		{
+			desc:      "written differently",
+			minLength: "05",
+			maxLength: "+100",
+		},
+		{
+			desc:      "changed",
+			minLength: "6",
+			maxLength: "100",
+			changes:   true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"guild_id":    "386659935687147520",
+				"name":        "hello-world",
+				"description": "Say hello",
+				"option": []interface{}{
+					map[string]interface{}{
+						"type":        3,
+						"name":        "message",
+						"description": "What to say",
+						"min_length":  tC.minLength,
+						"max_length":  tC.maxLength,
+					},
+				},
+			})
+
+			diff, err := resourceGuildCommand().Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatalf("failed to plan, %v", err)
+			}
+
+			if changes := diff != nil && !diff.Empty(); changes != tC.changes {
+				t.Errorf("expected changes: %v, got diff: %v", tC.changes, diff)
+			}
+		})
+	}
+}
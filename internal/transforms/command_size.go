@@ -0,0 +1,161 @@
+package transforms
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+// MaxCommandSize is how many characters Discord allows across a command's name, description,
+// and every option's name and description plus every choice's name and value.
+// Discord checks each locale on its own, counting localized text in place of the default where there is some.
+const MaxCommandSize = 4000
+
+// commandSizeWarningRatio is how full the budget can get before planning warns about it.
+const commandSizeWarningRatio = 0.9
+
+// CommandSize counts the characters of a command that Discord counts towards MaxCommandSize, in its largest locale.
+func CommandSize(command *client.InteractionCommand) int {
+	_, size := largestLocale(command)
+	return size
+}
+
+// largestLocale finds the locale using the most characters, and how many. The default text is the locale "".
+func largestLocale(command *client.InteractionCommand) (string, int) {
+	largest, largestSize := "", commandSize(command, "")
+
+	for _, locale := range commandLocales(command) {
+		if size := commandSize(command, locale); size > largestSize {
+			largest, largestSize = locale, size
+		}
+	}
+
+	return largest, largestSize
+}
+
+// commandLocales lists every locale localized anywhere in the command, sorted.
+func commandLocales(command *client.InteractionCommand) []string {
+	seen := map[string]bool{}
+	addLocales := func(localizations map[string]string) {
+		for locale := range localizations {
+			seen[locale] = true
+		}
+	}
+
+	addLocales(command.NameLocalizations)
+	addLocales(command.DescriptionLocalizations)
+
+	var addOptionLocales func(options []client.InteractionCommandOption)
+	addOptionLocales = func(options []client.InteractionCommandOption) {
+		for _, option := range options {
+			addLocales(option.NameLocalizations)
+			addLocales(option.DescriptionLocalizations)
+
+			for _, choice := range option.Choices {
+				addLocales(choice.NameLocalizations)
+			}
+
+			addOptionLocales(option.Options)
+		}
+	}
+	addOptionLocales(command.Options)
+
+	locales := make([]string, 0, len(seen))
+	for locale := range seen {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	return locales
+}
+
+func commandSize(command *client.InteractionCommand, locale string) int {
+	return commandOwnSize(command, locale) + optionsSize(command.Options, locale)
+}
+
+func commandOwnSize(command *client.InteractionCommand, locale string) int {
+	return localizedLength(command.Name, command.NameLocalizations, locale) + localizedLength(command.Description, command.DescriptionLocalizations, locale)
+}
+
+func optionsSize(options []client.InteractionCommandOption, locale string) (size int) {
+	for _, option := range options {
+		size += optionSize(option, locale)
+	}
+
+	return
+}
+
+func optionSize(option client.InteractionCommandOption, locale string) int {
+	size := localizedLength(option.Name, option.NameLocalizations, locale) + localizedLength(option.Description, option.DescriptionLocalizations, locale)
+
+	for _, choice := range option.Choices {
+		size += localizedLength(choice.Name, choice.NameLocalizations, locale) + utf8.RuneCountInString(fmt.Sprint(choice.Value))
+	}
+
+	return size + optionsSize(option.Options, locale)
+}
+
+// localizedLength is the length of value as shown in locale, falling back to value itself when it's not localized.
+func localizedLength(value string, localizations map[string]string, locale string) int {
+	if localized, ok := localizations[locale]; ok && localized != "" {
+		return utf8.RuneCountInString(localized)
+	}
+
+	return utf8.RuneCountInString(value)
+}
+
+// localeName describes a locale for messages.
+func localeName(locale string) string {
+	if locale == "" {
+		return "the default locale"
+	}
+
+	return "locale `" + locale + "`"
+}
+
+// CommandSizeWarning returns a warning if command uses more than 90% of MaxCommandSize but still fits, otherwise "".
+func CommandSizeWarning(command *client.InteractionCommand) string {
+	locale, size := largestLocale(command)
+	if size > MaxCommandSize || float64(size) <= commandSizeWarningRatio*MaxCommandSize {
+		return ""
+	}
+
+	return fmt.Sprintf("command `%s` uses %d of %d characters in %s, close to Discord's limit: %s", command.Name, size, MaxCommandSize, localeName(locale), commandSizeBreakdown(command, locale))
+}
+
+// validateCommandSize errors if command is over MaxCommandSize in any locale, listing what uses the most characters.
+func validateCommandSize(command *client.InteractionCommand, path string) (errs []error) {
+	locale, size := largestLocale(command)
+	if size <= MaxCommandSize {
+		return
+	}
+
+	prefix := ""
+	if path != "" {
+		prefix = path + ": "
+	}
+
+	return append(errs, fmt.Errorf("%scommand `%s` uses %d characters in %s, over Discord's limit of %d: %s", prefix, command.Name, size, localeName(locale), MaxCommandSize, commandSizeBreakdown(command, locale)))
+}
+
+// commandSizeBreakdown lists the size in locale of the command's own name and description, then each option, largest first.
+func commandSizeBreakdown(command *client.InteractionCommand, locale string) string {
+	options := make([]client.InteractionCommandOption, len(command.Options))
+	copy(options, command.Options)
+
+	sort.SliceStable(options, func(a, b int) bool {
+		return optionSize(options[a], locale) > optionSize(options[b], locale)
+	})
+
+	parts := []string{
+		fmt.Sprintf("name and description %d", commandOwnSize(command, locale)),
+	}
+	for _, option := range options {
+		parts = append(parts, fmt.Sprintf("option `%s` %d", option.Name, optionSize(option, locale)))
+	}
+
+	return strings.Join(parts, ", ")
+}
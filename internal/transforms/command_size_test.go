@@ -0,0 +1,143 @@
+package transforms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+)
+
+func TestCommandSize(t *testing.T) {
+	command := &client.InteractionCommand{
+		Name:        "color",
+		Description: "Pick a côlor",
+		Options: []client.InteractionCommandOption{
+			{
+				Type:        client.OptionTypeSubCommand,
+				Name:        "pick",
+				Description: "Pick one",
+				Options: []client.InteractionCommandOption{
+					{
+						Type:        client.OptionTypeInteger,
+						Name:        "shade",
+						Description: "How dark",
+						Choices: []client.InteractionCommandOptionChoice{
+							{Name: "light", Value: 1},
+							{Name: "dark", Value: 100},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// 5 + 12, then 4 + 8, then 5 + 8, then 5 + 1 and 4 + 3
+	expected := 55
+	if size := transforms.CommandSize(command); size != expected {
+		t.Errorf("size did not match, got: %d, wanted: %d", size, expected)
+	}
+
+	// Each locale is counted on its own, falling back to the default text, and the largest one counts.
+	command.Options[0].NameLocalizations = map[string]string{"de": "auswählen", "ja": "選ぶ"}
+	command.DescriptionLocalizations = map[string]string{"ja": strings.Repeat("色", 20)}
+
+	// de: 5 longer name. ja: 2 shorter name, 8 longer description.
+	expected += 6
+	if size := transforms.CommandSize(command); size != expected {
+		t.Errorf("localized size did not match, got: %d, wanted: %d", size, expected)
+	}
+}
+
+func TestCommandSizeLocales(t *testing.T) {
+	long := func(length int) string {
+		return strings.Repeat("n", length)
+	}
+
+	// The default text uses 2001 characters, de adds 1500 to the command name and ja adds 1500 to the option name.
+	// Counting the longest of each field would be 5001, but no single locale is over 3501.
+	command := &client.InteractionCommand{
+		Name:              "big",
+		NameLocalizations: map[string]string{"de": long(1503)},
+		Description:       long(1000),
+		Options: []client.InteractionCommandOption{
+			{
+				Type:              client.OptionTypeString,
+				Name:              "opt",
+				NameLocalizations: map[string]string{"ja": long(1503)},
+				Description:       long(995),
+			},
+		},
+	}
+
+	if size := transforms.CommandSize(command); size != 3501 {
+		t.Errorf("size did not match, got: %d, wanted: %d", size, 3501)
+	}
+
+	warning := transforms.CommandSizeWarning(command)
+	if warning != "" {
+		t.Errorf("expected no warning, got: %s", warning)
+	}
+
+	command.NameLocalizations["de"] = long(1700)
+	warning = transforms.CommandSizeWarning(command)
+	if !strings.Contains(warning, "locale `de`") || !strings.Contains(warning, "name and description 2700") {
+		t.Errorf("expected a warning about de, got: %q", warning)
+	}
+}
+
+func TestCommandSizeLimit(t *testing.T) {
+	choices := func(count int) []interface{} {
+		items := make([]interface{}, count)
+		for i := range items {
+			items[i] = choice(strings.Repeat("n", 50), strings.Repeat("v", 100), 0, 0)
+		}
+
+		return items
+	}
+
+	testCases := []struct {
+		desc    string
+		command map[string]interface{}
+		warns   bool
+		errors  bool
+	}{
+		{
+			desc:    "small",
+			command: command(option(3, "color", map[string]interface{}{"choice": choices(2)})),
+		},
+		{
+			desc: "close to the limit",
+			command: command(
+				option(3, "color", map[string]interface{}{"choice": choices(20)}),
+				option(3, "shade", map[string]interface{}{"choice": choices(5)}),
+			),
+			warns: true,
+		},
+		{
+			desc: "over the limit",
+			command: command(
+				option(3, "color", map[string]interface{}{"choice": choices(25)}),
+				option(3, "shade", map[string]interface{}{"choice": choices(5)}),
+			),
+			errors: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			warning := transforms.CommandSizeWarning(transforms.ExpandCommand(tC.command))
+			if (warning != "") != tC.warns {
+				t.Errorf("expected warning: %v, got: %q", tC.warns, warning)
+			}
+
+			errs := transforms.ValidateCommand(tC.command, "")
+			if (len(errs) != 0) != tC.errors {
+				t.Errorf("expected errors: %v, got: %v", tC.errors, errs)
+			}
+
+			if tC.errors && len(errs) == 1 && !strings.Contains(errs[0].Error(), "option `color` 3764, option `shade` 764") {
+				t.Errorf("expected options largest first in the error, got: %v", errs[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package transforms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+// ValidateCommand checks rules that span more than one attribute of a command, which a schema ValidateFunc can't see.
+// commandItem is shaped like the command schema; path is prepended to the attribute paths in errors.
+func ValidateCommand(commandItem map[string]interface{}, path string) (errs []error) {
+	return ValidateKnownCommand(commandItem, nil, path)
+}
+
+// ValidateKnownCommand is ValidateCommand for a planned command, where unknown names the top-level attributes whose values
+// aren't known yet. Those must be empty in commandItem. Checks that need them are skipped, except the size limit,
+// which they can only add to. Nothing about the options is checked until `option` is known.
+func ValidateKnownCommand(commandItem map[string]interface{}, unknown map[string]bool, path string) (errs []error) {
+	if !unknown["type"] {
+		errs = append(errs, validateCommandType(commandItem, unknown, path)...)
+	}
+
+	if unknown["option"] {
+		return
+	}
+
+	optionItems, _ := commandItem["option"].([]interface{})
+
+	errs = append(errs, validateOptionItems(optionItems, 0, attributePath(path, "option"))...)
+	errs = append(errs, validateCommandSize(ExpandCommand(commandItem), path)...)
+
+	return
+}
+
+// validateCommandType applies the rules that depend on the command's type, skipping attributes that are unknown.
+// Chat input commands need a lowercase name and a description. Context menu (user and message) commands may use spaces and capitals,
+// but can't have a description or options.
+func validateCommandType(commandItem map[string]interface{}, unknown map[string]bool, path string) (errs []error) {
+	typeName, _ := commandItem["type"].(string)
+	name, _ := commandItem["name"].(string)
+	description, _ := commandItem["description"].(string)
+	descriptionLocalizations, _ := commandItem["description_localizations"].(map[string]interface{})
+	optionItems, _ := commandItem["option"].([]interface{})
+
+	validateName := ValidateName
+	if typeName != "" && CommandTypes[typeName] != client.CommandTypeChatInput {
+		validateName = ValidateCommandName
+
+		if description != "" {
+			errs = append(errs, fmt.Errorf("%s: %s commands can't have a description, got: `%s`", attributePath(path, "description"), typeName, description))
+		}
+
+		if len(descriptionLocalizations) > 0 {
+			errs = append(errs, fmt.Errorf("%s: %s commands can't have description localizations", attributePath(path, "description_localizations"), typeName))
+		}
+
+		if len(optionItems) > 0 {
+			errs = append(errs, fmt.Errorf("%s: %s commands can't have options", attributePath(path, "option"), typeName))
+		}
+	} else if !unknown["description"] {
+		_, descriptionErrs := ValidateDescription(description, "description")
+		for _, err := range descriptionErrs {
+			errs = append(errs, fmt.Errorf("%s: %w", attributePath(path, "description"), err))
+		}
+	}
+
+	if !unknown["name"] {
+		_, nameErrs := validateName(name, "name")
+		for _, err := range nameErrs {
+			errs = append(errs, fmt.Errorf("%s: %w", attributePath(path, "name"), err))
+		}
+	}
+
+	if !unknown["name_localizations"] {
+		_, localizationErrs := ValidateLocalizations(validateName)(commandItem["name_localizations"], attributePath(path, "name_localizations"))
+		errs = append(errs, localizationErrs...)
+	}
+
+	return
+}
+
+// maxOptions is how many options Discord allows at each level, and how many choices an option may have.
+const maxOptions = 25
+
+// validateOptionItems checks a level of the option tree against Discord's nesting rules, then each option in it.
+// parentType is the type of the option holding this level, or 0 for the command's own options.
+func validateOptionItems(optionItems []interface{}, parentType int, path string) (errs []error) {
+	if len(optionItems) > maxOptions {
+		errs = append(errs, fmt.Errorf("%s: at most %d options are allowed, got: %d", path, maxOptions, len(optionItems)))
+	}
+
+	errs = append(errs, validateRequiredOrder(optionItems, path)...)
+
+	names := map[string]bool{}
+	firstSubCommand, firstParameter := "", ""
+
+	for i, optionItemIntf := range optionItems {
+		optionItem, _ := optionItemIntf.(map[string]interface{})
+		optionPath := attributePath(path, fmt.Sprint(i))
+
+		name, _ := optionItem["name"].(string)
+		if names[name] {
+			errs = append(errs, fmt.Errorf("%s: option name `%s` is used more than once at this level", optionPath, name))
+		}
+		names[name] = true
+
+		optionType, _ := optionItem["type"].(int)
+		choiceItems, _ := optionItem["choice"].([]interface{})
+		nestedItems, _ := optionItem["option"].([]interface{})
+
+		if optionType < client.OptionTypeSubCommand || optionType > client.OptionTypeAttachment {
+			errs = append(errs, fmt.Errorf("%s: option `%s` has unknown type %d, must be between %d and %d", optionPath, name, optionType, client.OptionTypeSubCommand, client.OptionTypeAttachment))
+		}
+
+		if len(setItems(optionItem["channel_types"])) > 0 && optionType != client.OptionTypeChannel {
+			errs = append(errs, fmt.Errorf("%s: only CHANNEL (%d) options can have channel_types, `%s` is type %d", attributePath(optionPath, "channel_types"), client.OptionTypeChannel, name, optionType))
+		}
+
+		errs = append(errs, validateOptionLimits(optionItem, optionType, name, optionPath)...)
+
+		switch {
+		case parentType == client.OptionTypeSubCommandGroup && optionType != client.OptionTypeSubCommand:
+			errs = append(errs, fmt.Errorf("%s: subcommand groups may only contain subcommands (type %d), got: `%s` of type %d", optionPath, client.OptionTypeSubCommand, name, optionType))
+		case parentType == client.OptionTypeSubCommand && isSubCommand(optionType):
+			errs = append(errs, fmt.Errorf("%s: subcommands can't contain subcommands or groups, got: `%s` of type %d", optionPath, name, optionType))
+		}
+
+		if isSubCommand(optionType) {
+			if firstSubCommand == "" {
+				firstSubCommand = name
+			}
+
+			if required, _ := optionItem["required"].(bool); required {
+				errs = append(errs, fmt.Errorf("%s: subcommand `%s` can't be required", attributePath(optionPath, "required"), name))
+			}
+
+			if len(choiceItems) > 0 {
+				errs = append(errs, fmt.Errorf("%s: subcommand `%s` can't have choices", attributePath(optionPath, "choice"), name))
+			}
+
+			errs = append(errs, validateOptionItems(nestedItems, optionType, attributePath(optionPath, "option"))...)
+			continue
+		}
+
+		if firstParameter == "" {
+			firstParameter = name
+		}
+
+		if len(nestedItems) > 0 {
+			errs = append(errs, fmt.Errorf("%s: only subcommands and subcommand groups can contain options, `%s` is type %d", attributePath(optionPath, "option"), name, optionType))
+		}
+
+		errs = append(errs, validateChoiceItems(choiceItems, optionType, name, attributePath(optionPath, "choice"))...)
+	}
+
+	if firstSubCommand != "" && firstParameter != "" {
+		errs = append(errs, fmt.Errorf("%s: subcommands and groups can't be mixed with other options at the same level, got subcommand `%s` next to option `%s`", path, firstSubCommand, firstParameter))
+	}
+
+	return
+}
+
+// validateOptionLimits checks min_value and max_value are integers within range on INTEGER options, numbers on NUMBER options,
+// that min_length and max_length are only used on STRING options, and that every minimum is at most its maximum.
+func validateOptionLimits(optionItem map[string]interface{}, optionType int, name string, path string) (errs []error) {
+	minValue, _ := optionItem["min_value"].(string)
+	maxValue, _ := optionItem["max_value"].(string)
+	minLength, _ := optionItem["min_length"].(string)
+	maxLength, _ := optionItem["max_length"].(string)
+
+	if optionType != client.OptionTypeInteger && optionType != client.OptionTypeNumber {
+		for _, attribute := range []string{"min_value", "max_value"} {
+			if value, _ := optionItem[attribute].(string); value != "" {
+				errs = append(errs, fmt.Errorf("%s: only INTEGER (4) and NUMBER (10) options can have %s, `%s` is type %d", attributePath(path, attribute), attribute, name, optionType))
+			}
+		}
+	} else {
+		minNumber, minErr := parseOptionValue(minValue, optionType)
+		maxNumber, maxErr := parseOptionValue(maxValue, optionType)
+
+		if minErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", attributePath(path, "min_value"), minErr))
+		}
+		if maxErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", attributePath(path, "max_value"), maxErr))
+		}
+
+		if minNumber != nil && maxNumber != nil && *minNumber > *maxNumber {
+			errs = append(errs, fmt.Errorf("%s: min_value of `%s` must be at most max_value, got: %s > %s", attributePath(path, "min_value"), name, minValue, maxValue))
+		}
+	}
+
+	if optionType != client.OptionTypeString {
+		for _, attribute := range []string{"min_length", "max_length"} {
+			if value, _ := optionItem[attribute].(string); value != "" {
+				errs = append(errs, fmt.Errorf("%s: only STRING (3) options can have %s, `%s` is type %d", attributePath(path, attribute), attribute, name, optionType))
+			}
+		}
+	} else {
+		minLengthValue, maxLengthValue := ExpandLength(minLength), ExpandLength(maxLength)
+		if minLengthValue != nil && maxLengthValue != nil && *minLengthValue > *maxLengthValue {
+			errs = append(errs, fmt.Errorf("%s: min_length of `%s` must be at most max_length, got: %d > %d", attributePath(path, "min_length"), name, *minLengthValue, *maxLengthValue))
+		}
+	}
+
+	return
+}
+
+// parseOptionValue parses a min_value or max_value, which must be an integer for INTEGER options. Unset values are nil.
+func parseOptionValue(value string, optionType int) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if optionType == client.OptionTypeInteger {
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("INTEGER options need a whole number, got: `%s`", value)
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected a number, got: `%s`", value)
+	}
+
+	if number < -maxChoiceNumber || number > maxChoiceNumber {
+		return nil, fmt.Errorf("must be between -2^53 and 2^53, got: `%s`", value)
+	}
+
+	return &number, nil
+}
+
+// isSubCommand is true for SUB_COMMAND and SUB_COMMAND_GROUP, the option types that hold other options rather than taking a value.
+func isSubCommand(optionType int) bool {
+	return optionType == client.OptionTypeSubCommand || optionType == client.OptionTypeSubCommandGroup
+}
+
+// validateRequiredOrder ensures required options come before optional ones, as Discord requires.
+// Options aren't reordered when sent, as Discord would then return them in an order that differs from the configuration.
+func validateRequiredOrder(optionItems []interface{}, path string) (errs []error) {
+	firstOptional, seenOptional := "", false
+
+	for i, optionItemIntf := range optionItems {
+		optionItem, _ := optionItemIntf.(map[string]interface{})
+		name, _ := optionItem["name"].(string)
+
+		if required, _ := optionItem["required"].(bool); !required {
+			if !seenOptional {
+				firstOptional, seenOptional = name, true
+			}
+			continue
+		}
+
+		if seenOptional {
+			errs = append(errs, fmt.Errorf("%s: required option `%s` must come before optional option `%s`", attributePath(path, fmt.Sprint(i)), name, firstOptional))
+		}
+	}
+
+	return
+}
+
+// choiceValueAttributes maps the option types that accept choices to the choice attribute holding their values.
+var choiceValueAttributes = map[int]string{
+	client.OptionTypeString:  "string_value",
+	client.OptionTypeInteger: "int_value",
+	client.OptionTypeNumber:  "float_value",
+}
+
+// Limits on choice values, see: https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-choice-structure
+const (
+	maxChoiceStringLength = 100
+	// maxChoiceNumber is 2^53, the largest integer Discord (and JavaScript) can represent exactly.
+	maxChoiceNumber = 1 << 53
+)
+
+// validateChoiceItems checks each choice against the type of the option holding it.
+// The SDK fills in zero values for unset attributes, so a zero int_value or float_value is treated as unset,
+// and is only an acceptable value for INTEGER and NUMBER options.
+func validateChoiceItems(choiceItems []interface{}, optionType int, optionName string, path string) (errs []error) {
+	valueAttribute, ok := choiceValueAttributes[optionType]
+	if !ok {
+		if len(choiceItems) > 0 {
+			errs = append(errs, fmt.Errorf("%s: option `%s` is type %d, only STRING (3), INTEGER (4) and NUMBER (10) options can have choices", attributePath(path, "0"), optionName, optionType))
+		}
+
+		return
+	}
+
+	for i, choiceItemIntf := range choiceItems {
+		choiceItem, _ := choiceItemIntf.(map[string]interface{})
+		choicePath := attributePath(path, fmt.Sprint(i))
+
+		stringValue, _ := choiceItem["string_value"].(string)
+		intValue, _ := choiceItem["int_value"].(int)
+		floatValue, _ := choiceItem["float_value"].(float64)
+
+		set := []string{}
+		if stringValue != "" {
+			set = append(set, "string_value")
+		}
+		if intValue != 0 {
+			set = append(set, "int_value")
+		}
+		if floatValue != 0 {
+			set = append(set, "float_value")
+		}
+
+		for _, attribute := range set {
+			if attribute != valueAttribute {
+				errs = append(errs, fmt.Errorf("%s: choice `%s` can't use %s on option `%s` of type %d, use %s instead", attributePath(choicePath, attribute), choiceItem["name"], attribute, optionName, optionType, valueAttribute))
+			}
+		}
+
+		switch optionType {
+		case client.OptionTypeString:
+			if stringValue == "" {
+				errs = append(errs, fmt.Errorf("%s: choice `%s` must set string_value", attributePath(choicePath, "string_value"), choiceItem["name"]))
+			}
+
+			if length := utf8.RuneCountInString(stringValue); length > maxChoiceStringLength {
+				errs = append(errs, fmt.Errorf("%s: choice `%s` value must be at most %d characters, got: %d", attributePath(choicePath, "string_value"), choiceItem["name"], maxChoiceStringLength, length))
+			}
+		case client.OptionTypeInteger:
+			if int64(intValue) < -maxChoiceNumber || int64(intValue) > maxChoiceNumber {
+				errs = append(errs, fmt.Errorf("%s: choice `%s` value must be between -2^53 and 2^53, got: %d", attributePath(choicePath, "int_value"), choiceItem["name"], intValue))
+			}
+		case client.OptionTypeNumber:
+			if floatValue < -maxChoiceNumber || floatValue > maxChoiceNumber {
+				errs = append(errs, fmt.Errorf("%s: choice `%s` value must be between -2^53 and 2^53, got: %g", attributePath(choicePath, "float_value"), choiceItem["name"], floatValue))
+			}
+		}
+	}
+
+	return
+}
+
+// attributePath joins attribute path parts with dots, like `option.0.choice.1`
+func attributePath(parts ...string) string {
+	nonEmpty := []string{}
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+
+	return strings.Join(nonEmpty, ".")
+}
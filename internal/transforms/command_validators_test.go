@@ -0,0 +1,412 @@
+package transforms_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+)
+
+func option(optionType int, name string, extra map[string]interface{}) map[string]interface{} {
+	item := map[string]interface{}{
+		"type":        optionType,
+		"name":        name,
+		"description": "an option",
+		"required":    false,
+		"choice":      []interface{}{},
+	}
+
+	for key, value := range extra {
+		item[key] = value
+	}
+
+	return item
+}
+
+func choice(name string, stringValue string, intValue int, floatValue float64) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         name,
+		"string_value": stringValue,
+		"int_value":    intValue,
+		"float_value":  floatValue,
+	}
+}
+
+func command(options ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":               "hello-world",
+		"description":        "a command",
+		"default_permission": true,
+		"option":             options,
+	}
+}
+
+// maxSafeInteger is 2^53, a variable so tests still compile where int is 32 bits.
+var maxSafeInteger int64 = 1 << 53
+
+func contextMenuCommand(typeName string, name string, description string) map[string]interface{} {
+	item := command()
+	item["type"] = typeName
+	item["name"] = name
+	item["description"] = description
+
+	return item
+}
+
+func TestValidateCommand(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		command  map[string]interface{}
+		expected int
+	}{
+		{
+			desc: "string choices",
+			command: command(option(3, "color", map[string]interface{}{
+				"choice": []interface{}{choice("red", "#ff0000", 0, 0)},
+			})),
+			expected: 0,
+		},
+		{
+			desc: "zero integer choice",
+			command: command(option(4, "count", map[string]interface{}{
+				"choice": []interface{}{choice("none", "", 0, 0)},
+			})),
+			expected: 0,
+		},
+		{
+			desc: "empty string choice",
+			command: command(option(3, "color", map[string]interface{}{
+				"choice": []interface{}{choice("red", "", 0, 0)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "two values",
+			command: command(option(4, "count", map[string]interface{}{
+				"choice": []interface{}{choice("one", "one", 1, 0)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "int value on a string option",
+			command: command(option(3, "color", map[string]interface{}{
+				"choice": []interface{}{choice("red", "", 1, 0)},
+			})),
+			expected: 2,
+		},
+		{
+			desc: "string value on a number option",
+			command: command(option(10, "size", map[string]interface{}{
+				"choice": []interface{}{choice("big", "big", 0, 0)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "choices on a boolean option",
+			command: command(option(5, "enabled", map[string]interface{}{
+				"choice": []interface{}{choice("yes", "yes", 0, 0), choice("no", "no", 0, 0)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "string value too long",
+			command: command(option(3, "color", map[string]interface{}{
+				"choice": []interface{}{choice("long", strings.Repeat("ü", 101), 0, 0)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "string value at the limit",
+			command: command(option(3, "color", map[string]interface{}{
+				"choice": []interface{}{choice("long", strings.Repeat("ü", 100), 0, 0)},
+			})),
+			expected: 0,
+		},
+		{
+			desc: "integer out of range",
+			command: command(option(4, "count", map[string]interface{}{
+				"choice": []interface{}{choice("max", "", int(maxSafeInteger), 0), choice("too big", "", int(maxSafeInteger+1), 0)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "number out of range",
+			command: command(option(10, "size", map[string]interface{}{
+				"choice": []interface{}{choice("too small", "", 0, -1e16)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "required options first",
+			command: command(
+				option(3, "message", map[string]interface{}{"required": true}),
+				option(6, "user", nil),
+			),
+			expected: 0,
+		},
+		{
+			desc: "required after optional",
+			command: command(
+				option(6, "user", nil),
+				option(3, "message", map[string]interface{}{"required": true}),
+				option(4, "count", map[string]interface{}{"required": true}),
+			),
+			expected: 2,
+		},
+		{
+			desc: "nested required after optional",
+			command: command(option(1, "send", map[string]interface{}{
+				"option": []interface{}{
+					option(6, "user", nil),
+					option(3, "message", map[string]interface{}{"required": true}),
+				},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "subcommand group",
+			command: command(
+				option(2, "role", map[string]interface{}{
+					"option": []interface{}{
+						option(1, "add", map[string]interface{}{
+							"option": []interface{}{option(8, "role", map[string]interface{}{"required": true})},
+						}),
+						option(1, "list", nil),
+					},
+				}),
+				option(1, "help", nil),
+			),
+			expected: 0,
+		},
+		{
+			desc: "subcommands mixed with options",
+			command: command(
+				option(1, "add", nil),
+				option(3, "message", nil),
+			),
+			expected: 1,
+		},
+		{
+			desc: "group containing an option",
+			command: command(option(2, "role", map[string]interface{}{
+				"option": []interface{}{option(3, "message", nil)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "subcommand containing a subcommand",
+			command: command(option(1, "role", map[string]interface{}{
+				"option": []interface{}{option(1, "add", nil)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "required subcommand with choices",
+			command: command(option(1, "add", map[string]interface{}{
+				"required": true,
+				"choice":   []interface{}{choice("red", "#ff0000", 0, 0)},
+			})),
+			expected: 2,
+		},
+		{
+			desc: "options under a plain option",
+			command: command(option(3, "message", map[string]interface{}{
+				"option": []interface{}{option(3, "other", nil)},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "duplicate names",
+			command: command(
+				option(3, "message", nil),
+				option(6, "message", nil),
+			),
+			expected: 1,
+		},
+		{
+			desc:     "unknown type",
+			command:  command(option(12, "message", nil)),
+			expected: 1,
+		},
+		{
+			desc:     "chat input without description",
+			command:  contextMenuCommand("chat_input", "hello-world", ""),
+			expected: 1,
+		},
+		{
+			desc:     "chat input with capitals",
+			command:  contextMenuCommand("chat_input", "Hello World", "a command"),
+			expected: 2,
+		},
+		{
+			desc:     "message command",
+			command:  contextMenuCommand("message", "Report Message", ""),
+			expected: 0,
+		},
+		{
+			desc: "localized user command",
+			command: func() map[string]interface{} {
+				item := contextMenuCommand("user", "Ban User", "")
+				item["name_localizations"] = map[string]interface{}{"de": "Benutzer Sperren", "ja": "ユーザーをBAN"}
+				return item
+			}(),
+			expected: 0,
+		},
+		{
+			desc:     "user command with description",
+			command:  contextMenuCommand("user", "Ban User", "Bans a user"),
+			expected: 1,
+		},
+		{
+			desc: "message command with options",
+			command: func() map[string]interface{} {
+				item := contextMenuCommand("message", "Report Message", "")
+				item["option"] = []interface{}{option(3, "reason", nil)}
+				return item
+			}(),
+			expected: 1,
+		},
+		{
+			desc: "channel types on a channel option",
+			command: command(option(7, "channel", map[string]interface{}{
+				"channel_types": []interface{}{"text", "voice"},
+			})),
+			expected: 0,
+		},
+		{
+			desc: "channel types on a string option",
+			command: command(option(3, "channel", map[string]interface{}{
+				"channel_types": []interface{}{"text"},
+			})),
+			expected: 1,
+		},
+		{
+			desc: "integer limits",
+			command: command(option(4, "count", map[string]interface{}{
+				"min_value": "0",
+				"max_value": "10",
+			})),
+			expected: 0,
+		},
+		{
+			desc: "fractional integer limit",
+			command: command(option(4, "count", map[string]interface{}{
+				"min_value": "0.5",
+			})),
+			expected: 1,
+		},
+		{
+			desc: "number limits",
+			command: command(option(10, "size", map[string]interface{}{
+				"min_value": "-0.5",
+				"max_value": "2.5",
+			})),
+			expected: 0,
+		},
+		{
+			desc: "min value over max value",
+			command: command(option(10, "size", map[string]interface{}{
+				"min_value": "3",
+				"max_value": "2.5",
+			})),
+			expected: 1,
+		},
+		{
+			desc: "value limits on a string option",
+			command: command(option(3, "message", map[string]interface{}{
+				"min_value": "0",
+				"max_value": "10",
+			})),
+			expected: 2,
+		},
+		{
+			desc: "length limits",
+			command: command(option(3, "message", map[string]interface{}{
+				"min_length": "0",
+				"max_length": "6000",
+			})),
+			expected: 0,
+		},
+		{
+			desc: "min length over max length",
+			command: command(option(3, "message", map[string]interface{}{
+				"min_length": "20",
+				"max_length": "10",
+			})),
+			expected: 1,
+		},
+		{
+			desc: "length limits on an integer option",
+			command: command(option(4, "count", map[string]interface{}{
+				"max_length": "10",
+			})),
+			expected: 1,
+		},
+		{
+			desc:     "too many options",
+			command:  command(manyOptions(26)...),
+			expected: 1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			errs := transforms.ValidateCommand(tC.command, "")
+
+			if len(errs) != tC.expected {
+				t.Errorf("expected %d errors, got: %v", tC.expected, errs)
+			}
+		})
+	}
+}
+
+func TestValidateKnownCommand(t *testing.T) {
+	emptyDescription := func(item map[string]interface{}) map[string]interface{} {
+		item["description"] = ""
+		return item
+	}
+
+	testCases := []struct {
+		desc     string
+		command  map[string]interface{}
+		unknown  map[string]bool
+		expected int
+	}{
+		{
+			desc:     "unknown description",
+			command:  emptyDescription(command()),
+			unknown:  map[string]bool{"description": true},
+			expected: 0,
+		},
+		{
+			desc:     "unknown description with invalid options",
+			command:  emptyDescription(command(option(3, "optional", nil), option(3, "required", map[string]interface{}{"required": true}))),
+			unknown:  map[string]bool{"description": true},
+			expected: 1,
+		},
+		{
+			desc:     "unknown options",
+			command:  emptyDescription(command()),
+			unknown:  map[string]bool{"option": true},
+			expected: 1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			errs := transforms.ValidateKnownCommand(tC.command, tC.unknown, "")
+
+			if len(errs) != tC.expected {
+				t.Errorf("expected %d errors, got: %v", tC.expected, errs)
+			}
+		})
+	}
+}
+
+func manyOptions(count int) []interface{} {
+	options := make([]interface{}, count)
+	for i := range options {
+		options[i] = option(3, fmt.Sprintf("option-%d", i), nil)
+	}
+
+	return options
+}
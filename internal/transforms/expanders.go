@@ -1,6 +1,33 @@
 package transforms
 
-import "github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+)
+
+// CommandTypes maps the command type names used in configuration to Discord's values.
+var CommandTypes = map[string]int{
+	"chat_input": client.CommandTypeChatInput,
+	"user":       client.CommandTypeUser,
+	"message":    client.CommandTypeMessage,
+}
+
+func ExpandCommand(commandItem map[string]interface{}) *client.InteractionCommand {
+	typeName, _ := commandItem["type"].(string)
+
+	return &client.InteractionCommand{
+		Type:                     CommandTypes[typeName],
+		Name:                     commandItem["name"].(string),
+		Description:              commandItem["description"].(string),
+		NameLocalizations:        ExpandLocalizations(commandItem["name_localizations"]),
+		DescriptionLocalizations: ExpandLocalizations(commandItem["description_localizations"]),
+		DefaultPermission:        client.Bool(commandItem["default_permission"].(bool)),
+		Options:                  ExpandOptions(commandItem["option"].([]interface{})),
+	}
+}
 
 func ExpandOptions(optionItems []interface{}) []client.InteractionCommandOption {
 	options := make([]client.InteractionCommandOption, len(optionItems))
@@ -8,59 +35,206 @@ func ExpandOptions(optionItems []interface{}) []client.InteractionCommandOption
 	for i, itemIntf := range optionItems {
 		item := itemIntf.(map[string]interface{})
 		option := client.InteractionCommandOption{
-			Type:        item["type"].(int),
-			Name:        item["name"].(string),
-			Description: item["description"].(string),
-			Required:    item["required"].(bool),
-			Choices:     ExpandChoices(item["choice"].([]interface{})),
+			Type:                     item["type"].(int),
+			Name:                     item["name"].(string),
+			Description:              item["description"].(string),
+			NameLocalizations:        ExpandLocalizations(item["name_localizations"]),
+			DescriptionLocalizations: ExpandLocalizations(item["description_localizations"]),
+			Required:                 client.Bool(item["required"].(bool)),
+			Choices:                  ExpandChoices(item["choice"].([]interface{}), item["type"].(int)),
+			ChannelTypes:             ExpandChannelTypes(item["channel_types"]),
+			MinValue:                 ExpandNumber(item["min_value"]),
+			MaxValue:                 ExpandNumber(item["max_value"]),
+			MinLength:                ExpandLength(item["min_length"]),
+			MaxLength:                ExpandLength(item["max_length"]),
+		}
+
+		// The deepest level of options has no `option` block.
+		if nestedItems, ok := item["option"].([]interface{}); ok {
+			option.Options = ExpandOptions(nestedItems)
 		}
 
 		options[i] = option
 	}
 
-	// TODO: validate nesting?
+	return options
+}
+
+// ExpandNumber parses an optional number, which is a string attribute so an explicit 0 can be told apart from unset.
+// Unset or invalid numbers are nil.
+func ExpandNumber(numberIntf interface{}) *float64 {
+	value, _ := numberIntf.(string)
+	if value == "" {
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
 
-	return SortRequiredOptions(options)
+	return &number
 }
 
-func SortRequiredOptions(options []client.InteractionCommandOption) []client.InteractionCommandOption {
-	required := []client.InteractionCommandOption{}
-	notRequired := []client.InteractionCommandOption{}
+// ExpandLength parses an optional length, which is a string attribute like ExpandNumber's.
+func ExpandLength(lengthIntf interface{}) *int {
+	value, _ := lengthIntf.(string)
+	if value == "" {
+		return nil
+	}
+
+	length, err := strconv.Atoi(value)
+	if err != nil {
+		return nil
+	}
 
-	for _, option := range options {
-		if option.Required {
-			required = append(required, option)
-		} else {
-			notRequired = append(notRequired, option)
+	return &length
+}
+
+// ChannelTypes maps the channel type names used in configuration to Discord's values.
+var ChannelTypes = map[string]int{
+	"text":                client.ChannelTypeGuildText,
+	"dm":                  client.ChannelTypeDM,
+	"voice":               client.ChannelTypeGuildVoice,
+	"group_dm":            client.ChannelTypeGroupDM,
+	"category":            client.ChannelTypeGuildCategory,
+	"announcement":        client.ChannelTypeGuildAnnouncement,
+	"announcement_thread": client.ChannelTypeAnnouncementThread,
+	"public_thread":       client.ChannelTypePublicThread,
+	"private_thread":      client.ChannelTypePrivateThread,
+	"stage":               client.ChannelTypeGuildStageVoice,
+	"directory":           client.ChannelTypeGuildDirectory,
+	"forum":               client.ChannelTypeGuildForum,
+	"media":               client.ChannelTypeGuildMedia,
+}
+
+// ExpandChannelTypes converts a set of channel type names to Discord's values, sorted so the request doesn't depend on set order.
+func ExpandChannelTypes(channelTypesIntf interface{}) []int {
+	names := setItems(channelTypesIntf)
+	if len(names) == 0 {
+		return nil
+	}
+
+	channelTypes := make([]int, 0, len(names))
+	for _, name := range names {
+		if channelType, ok := ChannelTypes[fmt.Sprint(name)]; ok {
+			channelTypes = append(channelTypes, channelType)
 		}
 	}
+	sort.Ints(channelTypes)
 
-	return append(required, notRequired...)
+	return channelTypes
 }
 
-func ExpandChoices(choiceItems []interface{}) []client.InteractionCommandOptionChoice {
+// setItems lists the items of a set attribute, which is a *schema.Set when read from the SDK, or a plain list elsewhere.
+func setItems(value interface{}) []interface{} {
+	switch value := value.(type) {
+	case interface{ List() []interface{} }:
+		return value.List()
+	case []interface{}:
+		return value
+	}
+
+	return nil
+}
+
+// ExpandChoices reads each choice's value from the attribute matching the option type.
+// The SDK fills in zero values for every attribute, so checking which ones are set isn't possible.
+func ExpandChoices(choiceItems []interface{}, optionType int) []client.InteractionCommandOptionChoice {
 	choices := make([]client.InteractionCommandOptionChoice, len(choiceItems))
 
 	for i, itemIntf := range choiceItems {
 		item := itemIntf.(map[string]interface{})
 		choice := client.InteractionCommandOptionChoice{
-			Name: item["name"].(string),
+			Name:              item["name"].(string),
+			NameLocalizations: ExpandLocalizations(item["name_localizations"]),
 		}
 
-		if item["string_value"] != nil {
+		switch optionType {
+		case client.OptionTypeInteger:
+			choice.Value = item["int_value"].(int)
+		case client.OptionTypeNumber:
+			choice.Value = item["float_value"].(float64)
+		default:
 			choice.Value = item["string_value"].(string)
 		}
 
-		if item["int_value"] != nil {
-			choice.Value = item["int_value"].(int)
+		choices[i] = choice
+	}
+
+	return choices
+}
+
+// ExpandLocalizations converts a map attribute of locale to text. Empty maps become nil, so nothing is sent.
+func ExpandLocalizations(localizationsIntf interface{}) map[string]string {
+	localizationItems, _ := localizationsIntf.(map[string]interface{})
+	if len(localizationItems) == 0 {
+		return nil
+	}
+
+	localizations := make(map[string]string, len(localizationItems))
+	for locale, value := range localizationItems {
+		localizations[locale], _ = value.(string)
+	}
+
+	return localizations
+}
+
+// Permission target sentinels, standing in for the IDs Discord uses for @everyone and all channels in a guild.
+const (
+	PermissionTargetEveryone    = "everyone"
+	PermissionTargetAllChannels = "all_channels"
+)
+
+// PermissionTypes maps the permission type names used in configuration to Discord's values.
+var PermissionTypes = map[string]int{
+	"role":    client.PermissionTypeRole,
+	"user":    client.PermissionTypeUser,
+	"channel": client.PermissionTypeChannel,
+}
+
+func ExpandPermissions(permissionItems []interface{}, guildID string) ([]client.ApplicationCommandPermission, error) {
+	permissions := make([]client.ApplicationCommandPermission, len(permissionItems))
+
+	for i, itemIntf := range permissionItems {
+		item := itemIntf.(map[string]interface{})
+		typeName := item["type"].(string)
+
+		permission := client.ApplicationCommandPermission{
+			ID:         item["id"].(string),
+			Type:       PermissionTypes[typeName],
+			Permission: item["allow"].(bool),
 		}
 
-		if item["float_value"] != nil {
-			choice.Value = item["float_value"].(float64)
+		// Already checked at plan time by ValidatePermissions, this only guards against mapping a sentinel to the wrong ID.
+		if err := validatePermissionTargetType(permission.ID, typeName); err != nil {
+			return nil, err
 		}
 
-		choices[i] = choice
+		switch permission.ID {
+		case PermissionTargetEveryone:
+			permission.ID = guildID
+		case PermissionTargetAllChannels:
+			allChannelsID, err := AllChannelsID(guildID)
+			if err != nil {
+				return nil, err
+			}
+
+			permission.ID = allChannelsID
+		}
+
+		permissions[i] = permission
 	}
 
-	return choices
+	return permissions, nil
+}
+
+// AllChannelsID is the ID Discord uses to mean every channel in a guild, which is the guild ID - 1.
+func AllChannelsID(guildID string) (string, error) {
+	id, err := strconv.ParseUint(guildID, 10, 64)
+	if err != nil || id == 0 {
+		return "", fmt.Errorf("guild ID is not a snowflake, got: %s", guildID)
+	}
+
+	return strconv.FormatUint(id-1, 10), nil
 }
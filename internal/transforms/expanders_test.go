@@ -0,0 +1,335 @@
+package transforms_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+)
+
+func TestPermissionsRoundTrip(t *testing.T) {
+	guildID := "386659935687147521"
+	items := []interface{}{
+		map[string]interface{}{"type": "role", "id": "everyone", "allow": false},
+		map[string]interface{}{"type": "channel", "id": "all_channels", "allow": true},
+		map[string]interface{}{"type": "user", "id": "62601275618889728", "allow": true},
+	}
+
+	permissions, err := transforms.ExpandPermissions(items, guildID)
+	if err != nil {
+		t.Fatalf("failed to expand, %v", err)
+	}
+
+	expected := []client.ApplicationCommandPermission{
+		{ID: guildID, Type: client.PermissionTypeRole, Permission: false},
+		{ID: "386659935687147520", Type: client.PermissionTypeChannel, Permission: true},
+		{ID: "62601275618889728", Type: client.PermissionTypeUser, Permission: true},
+	}
+	if !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expanded permissions did not match, got: %v, wanted: %v", permissions, expected)
+	}
+
+	flattened := transforms.FlattenPermissions(permissions, guildID)
+	if !reflect.DeepEqual(flattened, items) {
+		t.Errorf("flattened permissions did not match, got: %v, wanted: %v", flattened, items)
+	}
+}
+
+func TestPermissionSentinelsNeedMatchingType(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"type": "user", "id": "everyone", "allow": true},
+	}
+
+	_, err := transforms.ExpandPermissions(items, "386659935687147521")
+	if err == nil {
+		t.Errorf("expected an error for `everyone` on a user permission")
+	}
+}
+
+func TestChoicesRoundTrip(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		optionType int
+		item       map[string]interface{}
+		json       string
+	}{
+		{
+			desc:       "string",
+			optionType: client.OptionTypeString,
+			item:       map[string]interface{}{"name": "red", "name_localizations": map[string]interface{}{"pt-BR": "vermelho"}, "string_value": "#ff0000"},
+			json:       `{"name":"red","name_localizations":{"pt-BR":"vermelho"},"value":"#ff0000"}`,
+		},
+		{
+			desc:       "integer",
+			optionType: client.OptionTypeInteger,
+			item:       map[string]interface{}{"name": "answer", "name_localizations": map[string]interface{}{}, "int_value": 42},
+			json:       `{"name":"answer","value":42}`,
+		},
+		{
+			desc:       "zero integer",
+			optionType: client.OptionTypeInteger,
+			item:       map[string]interface{}{"name": "none", "name_localizations": map[string]interface{}{}, "int_value": 0},
+			json:       `{"name":"none","value":0}`,
+		},
+		{
+			desc:       "number",
+			optionType: client.OptionTypeNumber,
+			item:       map[string]interface{}{"name": "pi", "name_localizations": map[string]interface{}{}, "float_value": 3.14},
+			json:       `{"name":"pi","value":3.14}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			choices := transforms.ExpandChoices([]interface{}{tC.item}, tC.optionType)
+
+			encoded, err := json.Marshal(choices[0])
+			if err != nil {
+				t.Fatalf("failed to encode, %v", err)
+			}
+
+			if string(encoded) != tC.json {
+				t.Errorf("expanded choice did not match, got: %s, wanted: %s", encoded, tC.json)
+			}
+
+			decoded := client.InteractionCommandOptionChoice{}
+			err = json.Unmarshal(encoded, &decoded)
+			if err != nil {
+				t.Fatalf("failed to decode, %v", err)
+			}
+
+			flattened := transforms.FlattenChoices([]client.InteractionCommandOptionChoice{decoded}, tC.optionType)
+			if !reflect.DeepEqual(flattened[0], tC.item) {
+				t.Errorf("flattened choice did not match, got: %v, wanted: %v", flattened[0], tC.item)
+			}
+		})
+	}
+}
+
+func TestExplicitFalseIsSent(t *testing.T) {
+	command := transforms.ExpandCommand(map[string]interface{}{
+		"name":               "hello-world",
+		"description":        "a command",
+		"default_permission": false,
+		"option": []interface{}{
+			map[string]interface{}{
+				"type":        3,
+				"name":        "message",
+				"description": "What message do I send?",
+				"required":    false,
+				"choice":      []interface{}{},
+			},
+		},
+	})
+
+	encoded, err := json.Marshal(command)
+	if err != nil {
+		t.Fatalf("failed to encode, %v", err)
+	}
+
+	expected := `{"name":"hello-world","description":"a command","default_permission":false,"options":[{"type":3,"name":"message","description":"What message do I send?","required":false}]}`
+	if string(encoded) != expected {
+		t.Errorf("expanded command did not match, got: %s, wanted: %s", encoded, expected)
+	}
+
+	flattened := transforms.FlattenCommand(&client.InteractionCommand{Name: "hello-world"})
+	if flattened["default_permission"] != true {
+		t.Errorf("expected unset default_permission to flatten to Discord's default of true, got: %v", flattened["default_permission"])
+	}
+}
+
+func TestNestedOptionsRoundTrip(t *testing.T) {
+	optionItems := []interface{}{
+		map[string]interface{}{
+			"type":                      client.OptionTypeSubCommandGroup,
+			"name":                      "role",
+			"description":               "Manage roles",
+			"name_localizations":        map[string]interface{}{"ja": "ロール"},
+			"description_localizations": map[string]interface{}{"ja": "ロールを管理する"},
+			"required":                  false,
+			"choice":                    []interface{}{},
+			"channel_types":             []interface{}{},
+			"min_value":                 "",
+			"max_value":                 "",
+			"min_length":                "",
+			"max_length":                "",
+			"option": []interface{}{
+				map[string]interface{}{
+					"type":                      client.OptionTypeSubCommand,
+					"name":                      "add",
+					"description":               "Add a role",
+					"name_localizations":        map[string]interface{}{},
+					"description_localizations": map[string]interface{}{},
+					"required":                  false,
+					"choice":                    []interface{}{},
+					"channel_types":             []interface{}{},
+					"min_value":                 "",
+					"max_value":                 "",
+					"min_length":                "",
+					"max_length":                "",
+					"option": []interface{}{
+						map[string]interface{}{
+							"type":                      client.OptionTypeRole,
+							"name":                      "role",
+							"description":               "Role to add",
+							"name_localizations":        map[string]interface{}{},
+							"description_localizations": map[string]interface{}{},
+							"required":                  true,
+							"choice":                    []interface{}{},
+							"channel_types":             []interface{}{},
+							"min_value":                 "",
+							"max_value":                 "",
+							"min_length":                "",
+							"max_length":                "",
+						},
+					},
+				},
+			},
+		},
+		map[string]interface{}{
+			"type":                      client.OptionTypeSubCommand,
+			"name":                      "list",
+			"description":               "List roles",
+			"name_localizations":        map[string]interface{}{},
+			"description_localizations": map[string]interface{}{},
+			"required":                  false,
+			"choice":                    []interface{}{},
+			"channel_types":             []interface{}{},
+			"min_value":                 "",
+			"max_value":                 "",
+			"min_length":                "",
+			"max_length":                "",
+			"option":                    []interface{}{},
+		},
+	}
+
+	options := transforms.ExpandOptions(optionItems)
+
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		t.Fatalf("failed to encode, %v", err)
+	}
+
+	expected := `[{"type":2,"name":"role","description":"Manage roles","name_localizations":{"ja":"ロール"},"description_localizations":{"ja":"ロールを管理する"},"required":false,"options":[{"type":1,"name":"add","description":"Add a role","required":false,"options":[{"type":8,"name":"role","description":"Role to add","required":true}]}]},{"type":1,"name":"list","description":"List roles","required":false}]`
+	if string(encoded) != expected {
+		t.Errorf("expanded options did not match, got: %s, wanted: %s", encoded, expected)
+	}
+
+	decoded := []client.InteractionCommandOption{}
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		t.Fatalf("failed to decode, %v", err)
+	}
+
+	flattened := transforms.FlattenOptions(decoded)
+	if !reflect.DeepEqual(flattened, optionItems) {
+		t.Errorf("flattened options did not match, got: %v, wanted: %v", flattened, optionItems)
+	}
+}
+
+func TestCommandTypeRoundTrip(t *testing.T) {
+	for typeName, commandType := range transforms.CommandTypes {
+		t.Run(typeName, func(t *testing.T) {
+			command := transforms.ExpandCommand(map[string]interface{}{
+				"type":               typeName,
+				"name":               "Report Message",
+				"description":        "",
+				"default_permission": true,
+				"option":             []interface{}{},
+			})
+
+			if command.Type != commandType {
+				t.Errorf("expanded type did not match, got: %d, wanted: %d", command.Type, commandType)
+			}
+
+			flattened := transforms.FlattenCommand(command)
+			if flattened["type"] != typeName {
+				t.Errorf("flattened type did not match, got: %v, wanted: %s", flattened["type"], typeName)
+			}
+		})
+	}
+
+	// Discord may leave out the type of chat input commands.
+	if typeName := transforms.FlattenCommandType(0); typeName != "chat_input" {
+		t.Errorf("expected a missing type to flatten to chat_input, got: %s", typeName)
+	}
+}
+
+func TestChannelTypesRoundTrip(t *testing.T) {
+	optionItems := []interface{}{
+		map[string]interface{}{
+			"type":                      client.OptionTypeChannel,
+			"name":                      "channel",
+			"description":               "Where to post",
+			"name_localizations":        map[string]interface{}{},
+			"description_localizations": map[string]interface{}{},
+			"required":                  true,
+			"choice":                    []interface{}{},
+			"channel_types":             []interface{}{"text", "forum", "announcement_thread"},
+			"min_value":                 "",
+			"max_value":                 "",
+			"min_length":                "",
+			"max_length":                "",
+		},
+	}
+
+	options := transforms.ExpandOptions(optionItems)
+
+	expected := []int{client.ChannelTypeGuildText, client.ChannelTypeAnnouncementThread, client.ChannelTypeGuildForum}
+	if !reflect.DeepEqual(options[0].ChannelTypes, expected) {
+		t.Errorf("expanded channel types did not match, got: %v, wanted: %v", options[0].ChannelTypes, expected)
+	}
+
+	flattened := transforms.FlattenOptions(options)
+	channelTypes := flattened[0].(map[string]interface{})["channel_types"]
+	if !reflect.DeepEqual(channelTypes, []interface{}{"text", "announcement_thread", "forum"}) {
+		t.Errorf("flattened channel types did not match, got: %v", channelTypes)
+	}
+}
+
+func TestOptionLimitsKeepZero(t *testing.T) {
+	optionItems := []interface{}{
+		option(client.OptionTypeInteger, "count", map[string]interface{}{
+			"name_localizations":        map[string]interface{}{},
+			"description_localizations": map[string]interface{}{},
+			"channel_types":             []interface{}{},
+			"min_value":                 "0",
+			"max_value":                 "10",
+			"min_length":                "",
+			"max_length":                "",
+		}),
+		option(client.OptionTypeString, "message", map[string]interface{}{
+			"name_localizations":        map[string]interface{}{},
+			"description_localizations": map[string]interface{}{},
+			"channel_types":             []interface{}{},
+			"min_value":                 "",
+			"max_value":                 "",
+			"min_length":                "0",
+			"max_length":                "100",
+		}),
+	}
+
+	options := transforms.ExpandOptions(optionItems)
+
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		t.Fatalf("failed to encode, %v", err)
+	}
+
+	expected := `[{"type":4,"name":"count","description":"an option","required":false,"min_value":0,"max_value":10},{"type":3,"name":"message","description":"an option","required":false,"min_length":0,"max_length":100}]`
+	if string(encoded) != expected {
+		t.Errorf("expanded options did not match, got: %s, wanted: %s", encoded, expected)
+	}
+
+	decoded := []client.InteractionCommandOption{}
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		t.Fatalf("failed to decode, %v", err)
+	}
+
+	flattened := transforms.FlattenOptions(decoded)
+	if !reflect.DeepEqual(flattened, optionItems) {
+		t.Errorf("flattened options did not match, got: %v, wanted: %v", flattened, optionItems)
+	}
+}
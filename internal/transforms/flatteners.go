@@ -1,6 +1,10 @@
 package transforms
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
 	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
 )
 
@@ -11,10 +15,13 @@ func FlattenCommand(command *client.InteractionCommand) map[string]interface{} {
 
 	commandItem := make(map[string]interface{})
 
+	commandItem["type"] = FlattenCommandType(command.Type)
 	commandItem["name"] = command.Name
 	commandItem["id"] = command.ID
 	commandItem["description"] = command.Description
-	commandItem["default_permission"] = command.DefaultPermission
+	commandItem["name_localizations"] = FlattenLocalizations(command.NameLocalizations)
+	commandItem["description_localizations"] = FlattenLocalizations(command.DescriptionLocalizations)
+	commandItem["default_permission"] = command.DefaultPermission == nil || *command.DefaultPermission
 	commandItem["option"] = FlattenOptions(command.Options)
 
 	if command.GuildID != "" {
@@ -24,6 +31,22 @@ func FlattenCommand(command *client.InteractionCommand) map[string]interface{} {
 	return commandItem
 }
 
+// FlattenCommandType is the configuration name of a command type. Discord leaves out the type of chat input commands in some responses.
+// Types this provider doesn't know yet are kept as their number, so they show up as a diff rather than being mistaken for chat_input.
+func FlattenCommandType(commandType int) string {
+	if commandType == 0 {
+		return "chat_input"
+	}
+
+	for typeName, known := range CommandTypes {
+		if known == commandType {
+			return typeName
+		}
+	}
+
+	return fmt.Sprint(commandType)
+}
+
 func FlattenOptions(options []client.InteractionCommandOption) []interface{} {
 	items := make([]interface{}, len(options))
 
@@ -33,8 +56,20 @@ func FlattenOptions(options []client.InteractionCommandOption) []interface{} {
 		optionItem["type"] = option.Type
 		optionItem["name"] = option.Name
 		optionItem["description"] = option.Description
-		optionItem["required"] = option.Required
-		optionItem["choice"] = FlattenChoices(option.Choices)
+		optionItem["name_localizations"] = FlattenLocalizations(option.NameLocalizations)
+		optionItem["description_localizations"] = FlattenLocalizations(option.DescriptionLocalizations)
+		optionItem["required"] = option.Required != nil && *option.Required
+		optionItem["choice"] = FlattenChoices(option.Choices, option.Type)
+		optionItem["channel_types"] = FlattenChannelTypes(option.ChannelTypes)
+		optionItem["min_value"] = FlattenNumber(option.MinValue)
+		optionItem["max_value"] = FlattenNumber(option.MaxValue)
+		optionItem["min_length"] = FlattenLength(option.MinLength)
+		optionItem["max_length"] = FlattenLength(option.MaxLength)
+
+		// Only subcommands and groups hold options, and the deepest level of the schema has no `option` block to write to.
+		if option.Type == client.OptionTypeSubCommand || option.Type == client.OptionTypeSubCommandGroup {
+			optionItem["option"] = FlattenOptions(option.Options)
+		}
 
 		items[i] = optionItem
 	}
@@ -42,21 +77,58 @@ func FlattenOptions(options []client.InteractionCommandOption) []interface{} {
 	return items
 }
 
-func FlattenChoices(choices []client.InteractionCommandOptionChoice) []interface{} {
+// FlattenNumber formats an optional number the shortest way it can be written, like `5` or `0.25`. Unset numbers are "".
+func FlattenNumber(number *float64) string {
+	if number == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*number, 'f', -1, 64)
+}
+
+// FlattenLength formats an optional length. Unset lengths are "".
+func FlattenLength(length *int) string {
+	if length == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*length)
+}
+
+// FlattenChannelTypes converts Discord's channel type values to their names. Types this provider doesn't know yet are kept as their number.
+func FlattenChannelTypes(channelTypes []int) []interface{} {
+	items := make([]interface{}, len(channelTypes))
+
+	for i, channelType := range channelTypes {
+		items[i] = fmt.Sprint(channelType)
+
+		for name, known := range ChannelTypes {
+			if known == channelType {
+				items[i] = name
+			}
+		}
+	}
+
+	return items
+}
+
+// FlattenChoices writes each choice's value to the attribute matching the option type, so integers don't come back as floats.
+func FlattenChoices(choices []client.InteractionCommandOptionChoice, optionType int) []interface{} {
 	items := make([]interface{}, len(choices))
 
 	for i, choice := range choices {
 		choiceItem := make(map[string]interface{})
 
 		choiceItem["name"] = choice.Name
-
-		switch value := choice.Value.(type) {
-		case int, int32, int64:
-			choiceItem["int_value"] = value
-		case string:
-			choiceItem["string_value"] = value
-		case float32, float64:
-			choiceItem["float_value"] = value
+		choiceItem["name_localizations"] = FlattenLocalizations(choice.NameLocalizations)
+
+		switch optionType {
+		case client.OptionTypeInteger:
+			choiceItem["int_value"] = intValue(choice.Value)
+		case client.OptionTypeNumber:
+			choiceItem["float_value"] = floatValue(choice.Value)
+		default:
+			choiceItem["string_value"] = fmt.Sprint(choice.Value)
 		}
 
 		items[i] = choiceItem
@@ -64,3 +136,79 @@ func FlattenChoices(choices []client.InteractionCommandOptionChoice) []interface
 
 	return items
 }
+
+func FlattenLocalizations(localizations map[string]string) map[string]interface{} {
+	items := make(map[string]interface{}, len(localizations))
+
+	for locale, value := range localizations {
+		items[locale] = value
+	}
+
+	return items
+}
+
+func intValue(value interface{}) int {
+	switch value := value.(type) {
+	case json.Number:
+		if intValue, err := value.Int64(); err == nil {
+			return int(intValue)
+		}
+
+		floatValue, _ := value.Float64()
+		return int(floatValue)
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	}
+
+	return 0
+}
+
+func floatValue(value interface{}) float64 {
+	switch value := value.(type) {
+	case json.Number:
+		floatValue, _ := value.Float64()
+		return floatValue
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	}
+
+	return 0
+}
+
+func FlattenPermissions(permissions []client.ApplicationCommandPermission, guildID string) []interface{} {
+	items := make([]interface{}, len(permissions))
+	allChannelsID, _ := AllChannelsID(guildID)
+
+	for i, permission := range permissions {
+		permissionItem := make(map[string]interface{})
+
+		for typeName, permissionType := range PermissionTypes {
+			if permissionType == permission.Type {
+				permissionItem["type"] = typeName
+			}
+		}
+
+		switch {
+		case permission.Type == client.PermissionTypeRole && permission.ID == guildID:
+			permissionItem["id"] = PermissionTargetEveryone
+		case permission.Type == client.PermissionTypeChannel && permission.ID == allChannelsID:
+			permissionItem["id"] = PermissionTargetAllChannels
+		default:
+			permissionItem["id"] = permission.ID
+		}
+
+		permissionItem["allow"] = permission.Permission
+
+		items[i] = permissionItem
+	}
+
+	return items
+}
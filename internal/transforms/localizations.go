@@ -0,0 +1,229 @@
+package transforms
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LocalizationFileExtensions are the translation file formats ParseLocalizationFile reads.
+var LocalizationFileExtensions = []string{".json", ".yaml", ".yml", ".po"}
+
+// ParseLocalizationFile reads a translation file into a map of key to localized text, picking the format by the file extension.
+// JSON and YAML files may nest objects, which are joined into dotted keys like `config.set.value.description`.
+// gettext .po files use the msgid as the key, skipping untranslated and fuzzy entries.
+func ParseLocalizationFile(filename string, data []byte) (map[string]string, error) {
+	var decoded interface{}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		err := json.Unmarshal(data, &decoded)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not valid JSON, %w", filename, err)
+		}
+	case ".yaml", ".yml":
+		err := yaml.Unmarshal(data, &decoded)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not valid YAML, %w", filename, err)
+		}
+	case ".po":
+		localizations, err := parsePO(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid .po file, %w", filename, err)
+		}
+
+		return localizations, nil
+	default:
+		return nil, fmt.Errorf("%s is not a supported translation file, must be one of: %s", filename, strings.Join(LocalizationFileExtensions, ", "))
+	}
+
+	localizations := map[string]string{}
+	err := flattenLocalizationKeys(decoded, "", localizations)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return localizations, nil
+}
+
+// flattenLocalizationKeys walks nested objects, writing each string under its dotted key.
+func flattenLocalizationKeys(value interface{}, key string, localizations map[string]string) error {
+	switch value := value.(type) {
+	case string:
+		if key == "" {
+			return fmt.Errorf("expected an object of keys, got a string")
+		}
+
+		localizations[key] = value
+	case map[string]interface{}:
+		for childKey, child := range value {
+			err := flattenLocalizationKeys(child, attributePath(key, childKey), localizations)
+			if err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		// YAML decodes objects with keys of any type.
+		for childKey, child := range value {
+			err := flattenLocalizationKeys(child, attributePath(key, fmt.Sprint(childKey)), localizations)
+			if err != nil {
+				return err
+			}
+		}
+	case nil:
+		// An empty file or an empty key has nothing to localize.
+	default:
+		return fmt.Errorf("%s must be a string or an object, got: %v", key, value)
+	}
+
+	return nil
+}
+
+// parsePO reads the msgid and msgstr of each entry in a gettext .po file. Plural forms aren't used by Discord, so they're ignored.
+func parsePO(data []byte) (map[string]string, error) {
+	localizations := map[string]string{}
+
+	var msgid, msgstr *string
+	var current *string
+	fuzzy := false
+
+	flush := func() {
+		if msgid != nil && msgstr != nil && *msgid != "" && *msgstr != "" && !fuzzy {
+			localizations[*msgid] = *msgstr
+		}
+
+		msgid, msgstr, current, fuzzy = nil, nil, nil, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#,"):
+			if msgid != nil {
+				flush()
+			}
+
+			fuzzy = strings.Contains(line, "fuzzy")
+		case strings.HasPrefix(line, "#"):
+			// Other comments don't matter here.
+		case strings.HasPrefix(line, "msgid "):
+			if msgid != nil {
+				flush()
+			}
+
+			value, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(line, "msgid")))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: msgid must be a quoted string", lineNumber)
+			}
+
+			msgid = &value
+			current = msgid
+		case strings.HasPrefix(line, "msgstr "):
+			value, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(line, "msgstr")))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: msgstr must be a quoted string", lineNumber)
+			}
+
+			msgstr = &value
+			current = msgstr
+		case strings.HasPrefix(line, `"`):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: string continues neither a msgid nor a msgstr", lineNumber)
+			}
+
+			value, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quoted string", lineNumber)
+			}
+
+			*current += value
+		default:
+			// msgctxt, msgid_plural and msgstr[n] aren't used.
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	return localizations, nil
+}
+
+// LocalizationWarnings checks translations, a map of locale to key to localized text, for keys that some locales are missing,
+// and for text over the length Discord allows for what the key localizes. Keys ending in `name` are treated as names,
+// or choice names if they're below a `choice` or `choices` key, and keys ending in `description` as descriptions.
+func LocalizationWarnings(translations map[string]map[string]string) (warnings []string) {
+	locales := make([]string, 0, len(translations))
+	keySet := map[string]bool{}
+	for locale, localizations := range translations {
+		locales = append(locales, locale)
+		for key := range localizations {
+			keySet[key] = true
+		}
+	}
+	sort.Strings(locales)
+
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		missing := []string{}
+		limit := localizationLengthLimit(key)
+
+		for _, locale := range locales {
+			value, ok := translations[locale][key]
+			if !ok {
+				missing = append(missing, locale)
+				continue
+			}
+
+			if length := utf8.RuneCountInString(value); limit > 0 && length > limit {
+				warnings = append(warnings, fmt.Sprintf("%s in %s is %d characters, over Discord's limit of %d", key, locale, length, limit))
+			}
+		}
+
+		if len(missing) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s is missing from: %s", key, strings.Join(missing, ", ")))
+		}
+	}
+
+	return
+}
+
+// localizationLengthLimit is the maximum length of the text a key localizes, or 0 if it's not known.
+func localizationLengthLimit(key string) int {
+	segments := strings.Split(key, ".")
+
+	switch segments[len(segments)-1] {
+	case "name":
+		for _, segment := range segments {
+			if segment == "choice" || segment == "choices" {
+				return maxChoiceStringLength
+			}
+		}
+
+		return 32
+	case "description":
+		return 100
+	}
+
+	return 0
+}
@@ -0,0 +1,125 @@
+package transforms_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
+)
+
+func TestParseLocalizationFile(t *testing.T) {
+	expected := map[string]string{
+		"config.name":                      "configuração",
+		"config.set.value.description":     "Novo valor da \"configuração\"",
+		"config.set.value.choices.on.name": "ligado",
+	}
+
+	testCases := []struct {
+		filename string
+		data     string
+	}{
+		{
+			filename: "pt-BR.json",
+			data: `{
+				"config": {
+					"name": "configuração",
+					"set.value.description": "Novo valor da \"configuração\"",
+					"set": {"value": {"choices": {"on": {"name": "ligado"}}}}
+				}
+			}`,
+		},
+		{
+			filename: "pt-BR.yaml",
+			data: `
+config:
+  name: configuração
+  set:
+    value:
+      description: Novo valor da "configuração"
+      choices:
+        "on":
+          name: ligado
+`,
+		},
+		{
+			filename: "pt-BR.po",
+			data: `# Portuguese translations
+msgid ""
+msgstr ""
+"Language: pt_BR\n"
+
+msgid "config.name"
+msgstr "configuração"
+
+#. Shown next to the value parameter
+msgid "config.set.value.description"
+msgstr ""
+"Novo valor da "
+"\"configuração\""
+
+msgid "config.set.value.choices.on.name"
+msgstr "ligado"
+
+msgid "config.untranslated.description"
+msgstr ""
+
+#, fuzzy
+msgid "config.guessed.description"
+msgstr "Talvez"
+`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.filename, func(t *testing.T) {
+			localizations, err := transforms.ParseLocalizationFile(tC.filename, []byte(tC.data))
+			if err != nil {
+				t.Fatalf("failed to parse, %v", err)
+			}
+
+			if !reflect.DeepEqual(localizations, expected) {
+				t.Errorf("localizations did not match, got: %v, wanted: %v", localizations, expected)
+			}
+		})
+	}
+}
+
+func TestParseLocalizationFileErrors(t *testing.T) {
+	testCases := []struct {
+		filename string
+		data     string
+	}{
+		{filename: "de.json", data: `{"config": {"name": 1}}`},
+		{filename: "de.json", data: `["config"]`},
+		{filename: "de.yaml", data: "config: [a, b]"},
+		{filename: "de.po", data: "msgid config"},
+		{filename: "de.txt", data: "config.name=konfig"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.filename, func(t *testing.T) {
+			_, err := transforms.ParseLocalizationFile(tC.filename, []byte(tC.data))
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestLocalizationWarnings(t *testing.T) {
+	warnings := transforms.LocalizationWarnings(map[string]map[string]string{
+		"de": {
+			"config.name":                  "konfiguration-mit-einem-sehr-langen-namen",
+			"config.set.value.description": "Neuer Wert",
+		},
+		"ja": {
+			"config.name": "設定",
+		},
+	})
+
+	expected := []string{
+		"config.name in de is 41 characters, over Discord's limit of 32",
+		"config.set.value.description is missing from: ja",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("warnings did not match, got: %q, wanted: %q", warnings, expected)
+	}
+}
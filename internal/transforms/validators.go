@@ -3,11 +3,22 @@ package transforms
 import (
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/roleypoly/terraform-provider-discord-interactions/internal/client"
 )
 
+// maxOptionLength is the longest value Discord allows for a STRING option.
+const maxOptionLength = 6000
+
 var (
-	nameRegexp      = regexp.MustCompile(`^[\w-]{1,32}$`)
+	// nameRegexp is Discord's published name regex. Devanagari and Thai are listed as scripts since their vowel signs aren't letters.
+	nameRegexp      = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
 	snowflakeRegexp = regexp.MustCompile(`^[0-9]{1,}$`)
 )
 
@@ -22,7 +33,7 @@ func ValidateSnowflake(val interface{}, key string) (warns []string, errs []erro
 	return
 }
 
-// ValidateName ensures the input is lowercase, a-z, may include dashes, and a length of 1-32
+// ValidateName ensures the input is 1-32 letters, numbers, dashes or underscores, in any script, and lowercase where the script has case.
 func ValidateName(val interface{}, key string) (warns []string, errs []error) {
 	value := val.(string)
 
@@ -30,18 +41,47 @@ func ValidateName(val interface{}, key string) (warns []string, errs []error) {
 		errs = append(errs, fmt.Errorf("command name unacceptable: `%s`, refer to documentation: https://discord.com/developers/docs/interactions/slash-commands#create-global-application-command-json-params", value))
 	}
 
-	if value != strings.ToLower(value) {
-		errs = append(errs, fmt.Errorf("command name not lower case: `%s`, refer to documentation: https://discord.com/developers/docs/interactions/slash-commands#create-global-application-command-json-params", value))
+	if upper := upperCaseRunes(value); len(upper) > 0 {
+		errs = append(errs, fmt.Errorf("command name not lower case: `%s` (%s), refer to documentation: https://discord.com/developers/docs/interactions/slash-commands#create-global-application-command-json-params", value, strings.Join(upper, ", ")))
+	}
+
+	return
+}
+
+// ValidateCommandName ensures the input is 1-32 characters, which every type of command allows.
+// Chat input commands have stricter rules, which ValidateCommand checks once the command's type is known.
+func ValidateCommandName(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	length := utf8.RuneCountInString(value)
+
+	if length < 1 || length > 32 {
+		errs = append(errs, fmt.Errorf("command names must be 1-32 characters, got: `%s` (length %d)", value, length))
+	}
+
+	if strings.TrimSpace(value) != value {
+		errs = append(errs, fmt.Errorf("command names can't start or end with whitespace, got: `%s`", value))
 	}
 
 	return
 }
 
-// ValidateDescription ensures the input is 1-100 characters.
+// upperCaseRunes lists the characters in value that have a lowercase form, which Discord requires names to use.
+// Characters from scripts without case, like Japanese, have no lowercase form and are fine as is.
+func upperCaseRunes(value string) (upper []string) {
+	for _, r := range value {
+		if unicode.ToLower(r) != r {
+			upper = append(upper, fmt.Sprintf("`%c`", r))
+		}
+	}
+
+	return
+}
+
+// ValidateDescription ensures the input is 1-100 characters. Characters are counted as Unicode code points, like Discord does.
 // This may be used for more than descriptions, but is the primary use case. Option choice values also use this.
 func ValidateDescription(val interface{}, key string) (warns []string, errs []error) {
 	value := val.(string)
-	length := len(value)
+	length := utf8.RuneCountInString(value)
 
 	if length < 1 || length > 100 {
 		errs = append(errs, fmt.Errorf("command descriptions must be 1-100 characters, got: `%s` (length %d)", value, length))
@@ -49,3 +89,146 @@ func ValidateDescription(val interface{}, key string) (warns []string, errs []er
 
 	return
 }
+
+// Locales are the locales Discord accepts localizations for, see: https://discord.com/developers/docs/reference#locales
+var Locales = []string{
+	"id", "da", "de", "en-GB", "en-US", "es-ES", "es-419", "fr", "hr", "it", "lt", "hu", "nl", "no", "pl", "pt-BR",
+	"ro", "fi", "sv-SE", "vi", "tr", "cs", "el", "bg", "ru", "uk", "hi", "th", "zh-CN", "ja", "zh-TW", "ko",
+}
+
+// IsLocale is true if locale is one of Locales. Like Discord, this is case sensitive.
+func IsLocale(locale string) bool {
+	for _, known := range Locales {
+		if locale == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateLocalizations builds a validator for a map of locale to localized text,
+// checking every key is a supported locale and every value passes validateValue.
+func ValidateLocalizations(validateValue func(interface{}, string) ([]string, []error)) func(interface{}, string) ([]string, []error) {
+	return func(val interface{}, key string) (warns []string, errs []error) {
+		localizations, _ := val.(map[string]interface{})
+
+		locales := make([]string, 0, len(localizations))
+		for locale := range localizations {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+
+		for _, locale := range locales {
+			if !IsLocale(locale) {
+				errs = append(errs, fmt.Errorf("%s: `%s` is not a locale supported by Discord, must be one of: %s", key, locale, strings.Join(Locales, ", ")))
+				continue
+			}
+
+			value, _ := localizations[locale].(string)
+			valueWarns, valueErrs := validateValue(value, key+"."+locale)
+			warns = append(warns, valueWarns...)
+			for _, err := range valueErrs {
+				errs = append(errs, fmt.Errorf("%s.%s: %w", key, locale, err))
+			}
+		}
+
+		return
+	}
+}
+
+// ValidateNumber ensures the input is a number, or empty for unset.
+func ValidateNumber(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+
+	if _, err := strconv.ParseFloat(value, 64); value != "" && err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a number, got: `%s`", key, value))
+	}
+
+	return
+}
+
+// ValidateLength builds a validator ensuring the input is a whole number from minimum up to 6000, the longest string option Discord allows,
+// or empty for unset.
+func ValidateLength(minimum int) func(interface{}, string) ([]string, []error) {
+	return func(val interface{}, key string) (warns []string, errs []error) {
+		value := val.(string)
+		if value == "" {
+			return
+		}
+
+		length, err := strconv.Atoi(value)
+		if err != nil || length < minimum || length > maxOptionLength {
+			errs = append(errs, fmt.Errorf("%s must be a whole number from %d to %d, got: `%s`", key, minimum, maxOptionLength, value))
+		}
+
+		return
+	}
+}
+
+// NumbersEqual is true if both inputs are the same number, even if written differently, like `5` and `5.0`.
+func NumbersEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	numberA, errA := strconv.ParseFloat(a, 64)
+	numberB, errB := strconv.ParseFloat(b, 64)
+
+	return errA == nil && errB == nil && numberA == numberB
+}
+
+// ValidateDuration ensures the input is a positive duration parseable by time.ParseDuration, like `1s` or `500ms`.
+func ValidateDuration(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a duration, got: `%s`", key, value))
+		return
+	}
+
+	if duration <= 0 {
+		errs = append(errs, fmt.Errorf("%s must be positive, got: `%s`", key, value))
+	}
+
+	return
+}
+
+// ValidatePermissions checks the `everyone` and `all_channels` sentinels are only used with the type they stand for.
+// permissionItems are shaped like the `permission` blocks; path is prepended to errors.
+func ValidatePermissions(permissionItems []interface{}, path string) (errs []error) {
+	for _, itemIntf := range permissionItems {
+		item, _ := itemIntf.(map[string]interface{})
+		id, _ := item["id"].(string)
+		typeName, _ := item["type"].(string)
+
+		if err := validatePermissionTargetType(id, typeName); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+
+	return
+}
+
+func validatePermissionTargetType(id string, typeName string) error {
+	switch {
+	case id == PermissionTargetEveryone && PermissionTypes[typeName] != client.PermissionTypeRole:
+		return fmt.Errorf("`%s` can only be used with type `role`, got: `%s`", PermissionTargetEveryone, typeName)
+	case id == PermissionTargetAllChannels && PermissionTypes[typeName] != client.PermissionTypeChannel:
+		return fmt.Errorf("`%s` can only be used with type `channel`, got: `%s`", PermissionTargetAllChannels, typeName)
+	}
+
+	return nil
+}
+
+// ValidatePermissionTarget ensures the input is a snowflake ID, or one of the `everyone` or `all_channels` sentinels.
+func ValidatePermissionTarget(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+
+	if value == PermissionTargetEveryone || value == PermissionTargetAllChannels {
+		return
+	}
+
+	return ValidateSnowflake(val, key)
+}
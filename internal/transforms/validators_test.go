@@ -1,6 +1,7 @@
 package transforms_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/roleypoly/terraform-provider-discord-interactions/internal/transforms"
@@ -23,6 +24,82 @@ func TestNameValidator(t *testing.T) {
 			name:     "thisislongerthan32characterssoitshouldfail",
 			expected: false,
 		},
+		{
+			name:     "snake_case_2",
+			expected: true,
+		},
+		{
+			name:     "has space",
+			expected: false,
+		},
+		{
+			name:     "emoji-🎉",
+			expected: false,
+		},
+		{
+			name:     "привет",
+			expected: true,
+		},
+		{
+			name:     "Привет",
+			expected: false,
+		},
+		{
+			name:     "γειά-σου",
+			expected: true,
+		},
+		{
+			name:     "ΓΕΙΆ",
+			expected: false,
+		},
+		{
+			name:     "straße",
+			expected: true,
+		},
+		{
+			name:     "ẞtraße",
+			expected: false,
+		},
+		{
+			name:     "こんにちは",
+			expected: true,
+		},
+		{
+			name:     "役割を選ぶ",
+			expected: true,
+		},
+		{
+			name:     "한국어",
+			expected: true,
+		},
+		{
+			name:     "مرحبا",
+			expected: true,
+		},
+		{
+			name:     "שלום",
+			expected: true,
+		},
+		{
+			name:     "नमस्ते",
+			expected: true,
+		},
+		{
+			name:     "สวัสดี",
+			expected: true,
+		},
+		{
+			name:     "١٢٣",
+			expected: true,
+		},
+		{
+			name:     "三十二文字までの名前三十二文字までの名前三十二文字までの名前あい",
+			expected: true,
+		},
+		{
+			name:     "三十二文字までの名前三十二文字までの名前三十二文字までの名前あいう",
+			expected: false,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
@@ -53,6 +130,26 @@ func TestDescriptionValidator(t *testing.T) {
 			desc:     "hello world!hello world!hello world!hello world!hello world!hello world!hello world!hello world!hello world!hello world!",
 			expected: false,
 		},
+		{
+			desc:     "役割を選んでください。役割を選んでください。役割を選んでください。役割を選んでください。",
+			expected: true,
+		},
+		{
+			desc:     "Выберите роль, которую хотите получить на этом сервере, из списка ниже.",
+			expected: true,
+		},
+		{
+			desc:     "कृपया अपनी भूमिका चुनें",
+			expected: true,
+		},
+		{
+			desc:     strings.Repeat("字", 100),
+			expected: true,
+		},
+		{
+			desc:     strings.Repeat("字", 101),
+			expected: false,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run("description: "+tC.desc, func(t *testing.T) {
@@ -94,3 +191,104 @@ func TestSnowflake(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalizationsValidator(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		localizations map[string]interface{}
+		expected      int
+	}{
+		{
+			desc:          "valid",
+			localizations: map[string]interface{}{"pt-BR": "cargo", "ja": "役割", "zh-TW": "角色", "es-419": "rol"},
+			expected:      0,
+		},
+		{
+			desc:          "empty",
+			localizations: map[string]interface{}{},
+			expected:      0,
+		},
+		{
+			desc:          "unknown locale",
+			localizations: map[string]interface{}{"pt": "cargo", "en-us": "role"},
+			expected:      2,
+		},
+		{
+			desc:          "invalid name",
+			localizations: map[string]interface{}{"de": "Rolle", "fr": ""},
+			expected:      2,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, errs := transforms.ValidateLocalizations(transforms.ValidateName)(tC.localizations, "name_localizations")
+
+			if len(errs) != tC.expected {
+				t.Errorf("expected %d errors, got: %v", tC.expected, errs)
+			}
+		})
+	}
+}
+
+func TestLengthValidator(t *testing.T) {
+	testCases := []struct {
+		value    string
+		minimum  int
+		expected bool
+	}{
+		{value: "", minimum: 0, expected: true},
+		{value: "0", minimum: 0, expected: true},
+		{value: "0", minimum: 1, expected: false},
+		{value: "6000", minimum: 1, expected: true},
+		{value: "6001", minimum: 0, expected: false},
+		{value: "-1", minimum: 0, expected: false},
+		{value: "1.5", minimum: 0, expected: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.value, func(t *testing.T) {
+			warns, errs := transforms.ValidateLength(tC.minimum)(tC.value, "min_length")
+			result := len(errs) == 0
+
+			if result != tC.expected {
+				t.Errorf("did not match expectation, got: %v, %v", warns, errs)
+			}
+		})
+	}
+}
+
+func TestPermissionsValidator(t *testing.T) {
+	testCases := []struct {
+		typeName string
+		id       string
+		expected bool
+	}{
+		{typeName: "role", id: "everyone", expected: true},
+		{typeName: "user", id: "everyone", expected: false},
+		{typeName: "channel", id: "everyone", expected: false},
+		{typeName: "channel", id: "all_channels", expected: true},
+		{typeName: "role", id: "all_channels", expected: false},
+		{typeName: "user", id: "62601275618889728", expected: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.typeName+" "+tC.id, func(t *testing.T) {
+			errs := transforms.ValidatePermissions([]interface{}{
+				map[string]interface{}{"type": tC.typeName, "id": tC.id, "allow": true},
+			}, "permission")
+			result := len(errs) == 0
+
+			if result != tC.expected {
+				t.Errorf("did not match expectation, got: %v", errs)
+			}
+		})
+	}
+}
+
+func TestNumbersEqual(t *testing.T) {
+	if !transforms.NumbersEqual("5", "5.0") || !transforms.NumbersEqual("", "") {
+		t.Errorf("expected numbers written differently to be equal")
+	}
+
+	if transforms.NumbersEqual("5", "") || transforms.NumbersEqual("0", "") || transforms.NumbersEqual("5", "6") {
+		t.Errorf("expected different numbers, or a number and unset, not to be equal")
+	}
+}